@@ -0,0 +1,331 @@
+// Command paddle-replay delivers captured paddle alerts again.
+//
+// Alerts are read from a file with one url encoded body per line (form),
+// a file with one JSON object per line (jsonl), which are either flat
+// paddle alerts or journal entries, or a journal directory (journal).
+// Each alert is posted to target url with its original content type and
+// response status is reported for every alert.
+//
+//	paddle-replay -url http://localhost:8080/paddle -alert subscription_created -since 2019-04-15 captured.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/types"
+	"github.com/dennor/go-paddle/journal"
+	"github.com/dennor/go-paddle/mime"
+)
+
+const (
+	formatForm    = "form"
+	formatJSONL   = "jsonl"
+	formatJournal = "journal"
+)
+
+// timeFormats are accepted by -since and -until.
+var timeFormats = []string{time.RFC3339, types.DatetimeFormat, "2006-01-02"}
+
+type filter struct {
+	alerts         map[string]bool
+	since, until   time.Time
+	subscriptionID string
+}
+
+// match reports whether e passes filter. Alert time is its event_time
+// or time when it was received if alert has no event_time.
+func (f filter) match(e journal.Entry, fields events.Fields) bool {
+	if len(f.alerts) > 0 && !f.alerts[fields.GetAlertName()] {
+		return false
+	}
+	if f.subscriptionID != "" && fields["subscription_id"] != f.subscriptionID {
+		return false
+	}
+	if f.since.IsZero() && f.until.IsZero() {
+		return true
+	}
+	t := fields.GetEventTime()
+	if t.IsZero() {
+		t = e.ReceivedAt
+	}
+	if t.IsZero() {
+		return false
+	}
+	return !t.Before(f.since) && (f.until.IsZero() || t.Before(f.until))
+}
+
+func entryFields(e journal.Entry) (events.Fields, error) {
+	if strings.HasPrefix(e.ContentType, mime.ApplicationJSON) {
+		return events.FieldsFromJSON(e.Body)
+	}
+	return events.FieldsFromForm(e.Body)
+}
+
+func detectFormat(path string) (string, error) {
+	if path == "-" {
+		return formatForm, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case info.IsDir():
+		return formatJournal, nil
+	case filepath.Ext(path) == ".jsonl":
+		return formatJSONL, nil
+	}
+	return formatForm, nil
+}
+
+// readLines calls fn for every non empty line of r, offset is line number
+// counted from 0.
+func readLines(r io.Reader, fn func(offset int64, line []byte) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 16<<20)
+	var offset int64
+	for s.Scan() {
+		line := bytes.TrimSpace(s.Bytes())
+		if len(line) > 0 {
+			if err := fn(offset, append([]byte(nil), line...)); err != nil {
+				return fmt.Errorf("line %d: %w", offset+1, err)
+			}
+		}
+		offset++
+	}
+	return s.Err()
+}
+
+func readForm(r io.Reader) ([]journal.Entry, error) {
+	var entries []journal.Entry
+	err := readLines(r, func(offset int64, line []byte) error {
+		entries = append(entries, journal.Entry{Offset: offset, ContentType: mime.ApplicationForm, Body: line})
+		return nil
+	})
+	return entries, err
+}
+
+// readJSONL reads journal entries or flat JSON alerts, one per line.
+func readJSONL(r io.Reader) ([]journal.Entry, error) {
+	var entries []journal.Entry
+	err := readLines(r, func(offset int64, line []byte) error {
+		var e journal.Entry
+		if err := json.Unmarshal(line, &e); err == nil && len(e.Body) > 0 && e.ContentType != "" {
+			entries = append(entries, e)
+			return nil
+		}
+		entries = append(entries, journal.Entry{Offset: offset, ContentType: mime.ApplicationJSON, Body: line})
+		return nil
+	})
+	return entries, err
+}
+
+func readJournal(dir string, from int64) ([]journal.Entry, error) {
+	it, err := journal.ReadDir(dir, from)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var entries []journal.Entry
+	for it.Next() {
+		entries = append(entries, it.Entry())
+	}
+	return entries, it.Err()
+}
+
+func readEntries(path, format string, from int64) ([]journal.Entry, error) {
+	if format == formatJournal {
+		return readJournal(path, from)
+	}
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	switch format {
+	case formatForm:
+		return readForm(r)
+	case formatJSONL:
+		return readJSONL(r)
+	}
+	return nil, errors.New("unknown format " + format)
+}
+
+type replayer struct {
+	target string
+	client *http.Client
+	dryRun bool
+	// interval between requests, no limit if 0
+	interval time.Duration
+	filter   filter
+	out      io.Writer
+}
+
+// replay posts matching entries to target and returns number of alerts
+// which were not answered with 2xx status.
+func (r replayer) replay(ctx context.Context, entries []journal.Entry) (int, error) {
+	var tick <-chan time.Time
+	if r.interval > 0 && !r.dryRun {
+		t := time.NewTicker(r.interval)
+		defer t.Stop()
+		tick = t.C
+	}
+	failed, sent := 0, 0
+	for _, e := range entries {
+		fields, err := entryFields(e)
+		if err != nil {
+			fmt.Fprintf(r.out, "%d\t-\tskipped: %v\n", e.Offset, err)
+			failed++
+			continue
+		}
+		if !r.filter.match(e, fields) {
+			continue
+		}
+		name := fields.GetAlertName()
+		if r.dryRun {
+			fmt.Fprintf(r.out, "%d\t%s\tdry-run\n", e.Offset, name)
+			continue
+		}
+		if tick != nil && sent > 0 {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return failed, ctx.Err()
+			}
+		}
+		sent++
+		status, err := r.post(ctx, e)
+		if err != nil {
+			fmt.Fprintf(r.out, "%d\t%s\terror: %v\n", e.Offset, name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(r.out, "%d\t%s\t%d\n", e.Offset, name, status)
+		if status < 200 || status > 299 {
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (r replayer) post(ctx context.Context, e journal.Entry) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.target, bytes.NewReader(e.Body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(mime.ContentTypeHeader, e.ContentType)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range timeFormats {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid time " + s)
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("paddle-replay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	target := flags.String("url", "", "url alerts are posted to")
+	format := flags.String("format", "", "input format: form, jsonl or journal, detected from input if empty")
+	from := flags.Int64("offset", 0, "journal offset to start from")
+	alertNames := flags.String("alert", "", "comma separated alert names to replay")
+	since := flags.String("since", "", "replay alerts with event time at or after")
+	until := flags.String("until", "", "replay alerts with event time before")
+	subscriptionID := flags.String("subscription", "", "replay alerts of subscription id")
+	dryRun := flags.Bool("dry-run", false, "list matching alerts without posting them")
+	rate := flags.Float64("rate", 0, "maximum number of alerts posted per second, 0 means no limit")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of a single request")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: paddle-replay [flags] file|dir|-")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 || (*target == "" && !*dryRun) {
+		flags.Usage()
+		return 2
+	}
+	r := replayer{
+		target: *target,
+		client: &http.Client{Timeout: *timeout},
+		dryRun: *dryRun,
+		out:    stdout,
+	}
+	if *rate > 0 {
+		r.interval = time.Duration(float64(time.Second) / *rate)
+	}
+	var err error
+	if r.filter.since, err = parseTime(*since); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if r.filter.until, err = parseTime(*until); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	r.filter.subscriptionID = *subscriptionID
+	if *alertNames != "" {
+		r.filter.alerts = make(map[string]bool)
+		for _, name := range strings.Split(*alertNames, ",") {
+			r.filter.alerts[strings.TrimSpace(name)] = true
+		}
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		if *format, err = detectFormat(path); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	entries, err := readEntries(path, *format, *from)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	failed, err := r.replay(context.Background(), entries)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if failed > 0 {
+		fmt.Fprintf(stderr, "%d alerts failed\n", failed)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
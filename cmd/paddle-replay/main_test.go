@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/journal"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const captured = `alert_name=subscription_created&subscription_id=1&event_time=2019-04-15+07%3A37%3A53
+alert_name=subscription_updated&subscription_id=2&event_time=2019-04-16+07%3A37%3A53
+
+alert_name=subscription_cancelled&subscription_id=1&event_time=2019-04-17+07%3A37%3A53
+`
+
+type received struct {
+	mu          sync.Mutex
+	bodies      []string
+	contentType []string
+}
+
+func newServer(r *received, status func(body string) int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, _ := ioutil.ReadAll(req.Body)
+		r.mu.Lock()
+		r.bodies = append(r.bodies, string(b))
+		r.contentType = append(r.contentType, req.Header.Get(mime.ContentTypeHeader))
+		r.mu.Unlock()
+		rw.WriteHeader(status(string(b)))
+	}))
+}
+
+func TestReadJSONL(t *testing.T) {
+	assert := assert.New(t)
+	entries, err := readJSONL(strings.NewReader(`{"alert_name":"subscription_created","subscription_id":"1"}
+{"offset":7,"alert_name":"transfer_paid","content_type":"application/x-www-form-urlencoded","body":"YWxlcnRfbmFtZT10cmFuc2Zlcl9wYWlk"}
+`))
+	assert.NoError(err)
+	assert.Equal([]journal.Entry{
+		{ContentType: mime.ApplicationJSON, Body: []byte(`{"alert_name":"subscription_created","subscription_id":"1"}`)},
+		{Offset: 7, AlertName: "transfer_paid", ContentType: mime.ApplicationForm, Body: []byte("alert_name=transfer_paid")},
+	}, entries)
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paddle-replay")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "captured.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte(captured), 0600))
+
+	t.Run("PostsMatchingAlerts", func(t *testing.T) {
+		assert := assert.New(t)
+		var r received
+		srv := newServer(&r, func(string) int { return http.StatusOK })
+		defer srv.Close()
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-url", srv.URL, "-subscription", "1", path}, &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Len(r.bodies, 2)
+		assert.Equal([]string{mime.ApplicationForm, mime.ApplicationForm}, r.contentType)
+		assert.Equal("0\tsubscription_created\t200\n3\tsubscription_cancelled\t200\n", stdout.String())
+	})
+
+	t.Run("FiltersByAlertAndTime", func(t *testing.T) {
+		assert := assert.New(t)
+		var r received
+		srv := newServer(&r, func(string) int { return http.StatusOK })
+		defer srv.Close()
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-url", srv.URL, "-alert", "subscription_updated,subscription_cancelled", "-since", "2019-04-16", "-until", "2019-04-17", path}, &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Equal([]string{"alert_name=subscription_updated&subscription_id=2&event_time=2019-04-16+07%3A37%3A53"}, r.bodies)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		assert := assert.New(t)
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-dry-run", path}, &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Equal(3, strings.Count(stdout.String(), "dry-run"))
+	})
+
+	t.Run("ReportsFailures", func(t *testing.T) {
+		assert := assert.New(t)
+		var r received
+		srv := newServer(&r, func(body string) int {
+			if strings.Contains(body, "subscription_updated") {
+				return http.StatusNotFound
+			}
+			return http.StatusOK
+		})
+		defer srv.Close()
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-url", srv.URL, path}, &stdout, &stderr)
+		assert.Equal(1, code)
+		assert.Contains(stdout.String(), "1\tsubscription_updated\t404\n")
+		assert.Contains(stderr.String(), "1 alerts failed")
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		var r received
+		srv := newServer(&r, func(string) int { return http.StatusOK })
+		defer srv.Close()
+		start := time.Now()
+		code := run([]string{"-url", srv.URL, "-rate", "20", path}, ioutil.Discard, ioutil.Discard)
+		assert.Equal(0, code)
+		assert.True(time.Since(start) >= 100*time.Millisecond, "3 alerts at 20/s must take at least 100ms")
+	})
+
+	t.Run("Journal", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		jdir := filepath.Join(dir, "journal")
+		j, err := journal.Open(jdir, journal.Options{})
+		require.NoError(err)
+		for _, body := range []string{`{"alert_name":"transfer_paid"}`, `{"alert_name":"transfer_created"}`} {
+			_, err := j.Append(&journal.Entry{ReceivedAt: time.Now(), ContentType: mime.ApplicationJSON, Body: []byte(body)})
+			require.NoError(err)
+		}
+		require.NoError(j.Close())
+		var r received
+		srv := newServer(&r, func(string) int { return http.StatusOK })
+		defer srv.Close()
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-url", srv.URL, "-offset", "1", jdir}, &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Equal([]string{`{"alert_name":"transfer_created"}`}, r.bodies)
+		assert.Equal([]string{mime.ApplicationJSON}, r.contentType)
+	})
+}
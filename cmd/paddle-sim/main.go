@@ -0,0 +1,164 @@
+// Command paddle-sim posts correctly signed paddle classic alerts to a
+// local endpoint.
+//
+// Alerts are filled with realistic values, which can be overridden with
+// -set flags or a JSON template file. Public key matching the signing key
+// is printed first, so it can be configured in the application under test.
+//
+//	paddle-sim -url http://localhost:8080/paddle -alert subscription_created -set subscription_plan_id=123
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/types"
+	"github.com/dennor/go-paddle/sim"
+)
+
+// generateKey is replaced in tests, generating 4096 bit key is slow.
+var generateKey = sim.GenerateKey
+
+// overrides collects repeated -set key=value flags.
+type overrides events.Fields
+
+func (o overrides) String() string {
+	return fmt.Sprint(map[string]string(o))
+}
+
+func (o overrides) Set(s string) error {
+	i := strings.IndexByte(s, '=')
+	if i <= 0 {
+		return errors.New("expected key=value")
+	}
+	o[s[:i]] = s[i+1:]
+	return nil
+}
+
+func readTemplate(path string) (events.Fields, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return events.FieldsFromJSON(b)
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("paddle-sim", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	target := flags.String("url", "", "url alerts are posted to")
+	alertNames := flags.String("alert", "", "comma separated names of alerts to send")
+	templatePath := flags.String("template", "", "JSON file with fields overriding defaults")
+	keyPath := flags.String("key", "", "PEM encoded RSA private key, new key is generated if empty")
+	dryRun := flags.Bool("dry-run", false, "print signed bodies instead of posting them")
+	list := flags.Bool("list", false, "list supported alerts")
+	set := overrides{}
+	flags.Var(set, "set", "field override as key=value, can be repeated")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *list {
+		fmt.Fprintln(stdout, strings.Join(sim.AlertNames(), "\n"))
+		return 0
+	}
+	if *alertNames == "" || (*target == "" && !*dryRun) {
+		flags.Usage()
+		return 2
+	}
+	template, err := readTemplate(*templatePath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	var alerts []events.Fields
+	for _, name := range strings.Split(*alertNames, ",") {
+		name = strings.TrimSpace(name)
+		f, ok := sim.Defaults(name)
+		if !ok {
+			fmt.Fprintln(stderr, name+" is not a supported alert, see -list")
+			return 2
+		}
+		f["event_time"] = time.Now().UTC().Format(types.DatetimeFormat)
+		for _, o := range []events.Fields{template, events.Fields(set)} {
+			for k, v := range o {
+				f[k] = v
+			}
+		}
+		alerts = append(alerts, f)
+	}
+	signer := sim.Signer{}
+	if *keyPath != "" {
+		b, err := ioutil.ReadFile(*keyPath)
+		if err == nil {
+			signer.Key, err = sim.ParsePrivateKeyPEM(b)
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	} else if signer.Key, err = generateKey(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	pub, err := sim.PublicKeyPEM(&signer.Key.PublicKey)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s", pub)
+	failed := 0
+	for _, f := range alerts {
+		if err := signer.Sign(f); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		name := f.GetAlertName()
+		if *dryRun {
+			fmt.Fprintf(stdout, "%s\t%s\n", name, sim.Encode(f))
+			continue
+		}
+		status, err := post(*target, f)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s\terror: %v\n", name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(stdout, "%s\t%d\n", name, status)
+		if status < 200 || status > 299 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func post(target string, f events.Fields) (int, error) {
+	req, err := sim.NewRequest(target, f)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return 0, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/router"
+	"github.com/dennor/go-paddle/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	generateKey = func() (*rsa.PrivateKey, error) { return test.Key, nil }
+}
+
+func TestRun(t *testing.T) {
+	t.Run("PostsSignedAlerts", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir, err := ioutil.TempDir("", "paddle-sim")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+		templatePath := filepath.Join(dir, "template.json")
+		require.NoError(ioutil.WriteFile(templatePath, []byte(`{"passthrough":"from template","quantity":3}`), 0600))
+
+		var got []*subscription.Created
+		var handler http.Handler
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			handler.ServeHTTP(rw, req)
+		}))
+		defer srv.Close()
+		var stdout, stderr bytes.Buffer
+		// key is printed before alerts are posted, router is created lazily
+		handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			block, _ := pem.Decode(stdout.Bytes())
+			require.NotNil(block)
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			require.NoError(err)
+			router.NewRouter(router.Config{
+				Verifier: events.RSAVerifier(signature.RSA{PublicKey: key.(*rsa.PublicKey)}),
+				SubscriptionCreated: router.SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+					got = append(got, e)
+				}),
+				Unhandled: router.UnhandledAcknowledge,
+			}).Handler().ServeHTTP(rw, req)
+		})
+		code := run([]string{
+			"-url", srv.URL,
+			"-alert", "subscription_created",
+			"-template", templatePath,
+			"-set", "subscription_plan_id=123",
+		}, &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Contains(stdout.String(), "subscription_created\t200\n")
+		require.Len(got, 1)
+		assert.Equal(123, got[0].SubscriptionPlanID)
+		assert.Equal(3, got[0].Quantity)
+		assert.Equal("from template", got[0].Passthrough)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		assert := assert.New(t)
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-dry-run", "-alert", "transfer_paid", "-set", "amount=1.5"}, &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Contains(stdout.String(), "-----BEGIN PUBLIC KEY-----")
+		assert.Contains(stdout.String(), "transfer_paid\t")
+		assert.Contains(stdout.String(), "amount=1.5&")
+		assert.Contains(stdout.String(), "p_signature=")
+	})
+
+	t.Run("UnknownAlert", func(t *testing.T) {
+		var stderr bytes.Buffer
+		assert.Equal(t, 2, run([]string{"-dry-run", "-alert", "unknown"}, ioutil.Discard, &stderr))
+		assert.Contains(t, stderr.String(), "unknown is not a supported alert")
+	})
+
+	t.Run("List", func(t *testing.T) {
+		var stdout bytes.Buffer
+		assert.Equal(t, 0, run([]string{"-list"}, &stdout, ioutil.Discard))
+		assert.Len(t, strings.Fields(stdout.String()), 17)
+	})
+}
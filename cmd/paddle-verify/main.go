@@ -0,0 +1,259 @@
+// Command paddle-verify checks p_signature of captured paddle alert offline.
+//
+// It decodes body the same way middleware.Event does, prints decoded event
+// as JSON together with the php serialized string which was hashed and
+// reports whether signature verifies. If it does not, fields which differ
+// between request body and decoded event are listed.
+//
+//	paddle-verify -key paddle.pem body.txt
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/middleware"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/dennor/go-paddle/signature"
+)
+
+const signatureField = "p_signature"
+
+// detectContentType guesses content type of captured body.
+func detectContentType(b []byte) string {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		return mime.ApplicationJSON
+	}
+	return mime.ApplicationForm
+}
+
+func decode(contentType string, body []byte) (events.Event, events.Fields, error) {
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set(mime.ContentTypeHeader, contentType)
+	ev, err := (&middleware.Event{EventConfig: middleware.EventConfig{
+		SkipContext:  true,
+		UnknownAsRaw: true,
+	}}).EventFromRequest()(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	var fields events.Fields
+	if strings.HasPrefix(contentType, mime.ApplicationJSON) {
+		fields, err = events.FieldsFromJSON(body)
+	} else {
+		fields, err = events.FieldsFromForm(body)
+	}
+	return ev, fields, err
+}
+
+// parsePHPArray decodes php serialized array of scalars into map, values
+// are formatted the way php casts them to string.
+func parsePHPArray(b []byte) (map[string]string, error) {
+	errSyntax := errors.New("invalid php serialized array")
+	s := string(b)
+	readUntil := func(sep byte) (string, error) {
+		i := strings.IndexByte(s, sep)
+		if i < 0 {
+			return "", errSyntax
+		}
+		v := s[:i]
+		s = s[i+1:]
+		return v, nil
+	}
+	readValue := func() (string, error) {
+		if len(s) < 2 {
+			return "", errSyntax
+		}
+		t := s[0]
+		if t == 'N' && s[1] == ';' {
+			s = s[2:]
+			return "", nil
+		}
+		if s[1] != ':' {
+			return "", errSyntax
+		}
+		s = s[2:]
+		if t != 's' {
+			v, err := readUntil(';')
+			if t == 'b' && v == "0" {
+				v = ""
+			}
+			return v, err
+		}
+		ls, err := readUntil(':')
+		if err != nil {
+			return "", err
+		}
+		l, err := strconv.Atoi(ls)
+		if err != nil || len(s) < l+3 || s[0] != '"' || s[l+1:l+3] != `";` {
+			return "", errSyntax
+		}
+		v := s[1 : l+1]
+		s = s[l+3:]
+		return v, nil
+	}
+	if !strings.HasPrefix(s, "a:") {
+		return nil, errSyntax
+	}
+	s = s[2:]
+	ns, err := readUntil(':')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(ns)
+	if err != nil || !strings.HasPrefix(s, "{") {
+		return nil, errSyntax
+	}
+	s = s[1:]
+	m := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		k, err := readValue()
+		if err != nil {
+			return nil, err
+		}
+		v, err := readValue()
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+	}
+	if s != "}" {
+		return nil, errSyntax
+	}
+	return m, nil
+}
+
+// diffFields lists fields which differ between request body and fields
+// serialized from decoded event.
+func diffFields(body events.Fields, decoded map[string]string) []string {
+	keys := make(map[string]bool, len(body)+len(decoded))
+	for k := range body {
+		keys[k] = true
+	}
+	for k := range decoded {
+		keys[k] = true
+	}
+	delete(keys, signatureField)
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	var diff []string
+	for _, k := range sorted {
+		bv, inBody := body[k]
+		dv, inDecoded := decoded[k]
+		switch {
+		case !inDecoded:
+			diff = append(diff, fmt.Sprintf("- %s: %q is not decoded", k, bv))
+		case !inBody:
+			diff = append(diff, fmt.Sprintf("+ %s: %q is not in body", k, dv))
+		case bv != dv:
+			diff = append(diff, fmt.Sprintf("~ %s: body %q, decoded %q", k, bv, dv))
+		}
+	}
+	return diff
+}
+
+func verifyResult(err error) string {
+	if err == nil {
+		return "valid"
+	}
+	return "invalid: " + err.Error()
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("paddle-verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	keyPath := flags.String("key", "", "PEM encoded paddle public key")
+	contentType := flags.String("content-type", "", "content type of body, detected from body if empty")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: paddle-verify -key key.pem [file|-]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *keyPath == "" || flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	key, err := signature.LoadPublicKeyFile(*keyPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	r := stdin
+	if path := flags.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	body = bytes.TrimSpace(body)
+	if *contentType == "" {
+		*contentType = detectContentType(body)
+	}
+	fmt.Fprintf(stdout, "alert name: %s\n", middleware.AlertName(*contentType, body))
+	ev, fields, err := decode(*contentType, body)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	b, err := json.MarshalIndent(ev, "", "  ")
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "event %T:\n%s\n", ev, b)
+	serialized, err := ev.Serialize()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "serialized:\n%s\n", serialized)
+	verifier := events.RSAVerifier(signature.RSA{PublicKey: key})
+	typedErr := verifier.Verify(ev)
+	fmt.Fprintf(stdout, "signature: %s\n", verifyResult(typedErr))
+	if typedErr == nil {
+		return 0
+	}
+	fieldsErr := verifier.Verify(fields)
+	fmt.Fprintf(stdout, "signature of raw fields: %s\n", verifyResult(fieldsErr))
+	if decoded, err := parsePHPArray(serialized); err == nil {
+		if diff := diffFields(fields, decoded); len(diff) > 0 {
+			fmt.Fprintf(stdout, "differing fields:\n  %s\n", strings.Join(diff, "\n  "))
+		}
+	}
+	if fieldsErr == nil {
+		fmt.Fprintln(stdout, "raw fields verify, enable VerifyFields to accept this alert")
+		return 0
+	}
+	return 1
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePHPArray(t *testing.T) {
+	assert := assert.New(t)
+	m, err := parsePHPArray([]byte(`a:5:{s:1:"a";s:3:"x;y";s:1:"b";i:5;s:1:"c";b:0;s:1:"d";N;s:1:"e";d:1.5;}`))
+	assert.NoError(err)
+	assert.Equal(map[string]string{"a": "x;y", "b": "5", "c": "", "d": "", "e": "1.5"}, m)
+	_, err = parsePHPArray([]byte(`a:1:{s:5:"a";s:0:"";}`))
+	assert.Error(err)
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paddle-verify")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	der, err := x509.MarshalPKIXPublicKey(&test.Key.PublicKey)
+	require.NoError(t, err)
+	keyPath := filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	fields := map[string]string{
+		"alert_name": "transfer_paid",
+		"amount":     "1.23",
+		"currency":   "PLN",
+		"event_time": "2019-04-15 07:37:53",
+		"payout_id":  "2",
+		"status":     "closed",
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert := assert.New(t)
+		d := test.Sign(fields)
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-key", keyPath}, strings.NewReader(d.URL), &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		out := stdout.String()
+		assert.Contains(out, "alert name: transfer_paid\n")
+		assert.Contains(out, "event *alerts.TransferPaid:\n")
+		assert.Contains(out, `"alert_name": "transfer_paid"`)
+		assert.Contains(out, "serialized:\na:")
+		assert.Contains(out, "signature: valid\n")
+	})
+
+	t.Run("ValidJSONFile", func(t *testing.T) {
+		assert := assert.New(t)
+		d := test.Sign(fields)
+		path := filepath.Join(dir, "body.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(d.JSON), 0600))
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-key", keyPath, path}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		assert.Contains(stdout.String(), "signature: valid\n")
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		assert := assert.New(t)
+		withUnknown := map[string]string{"new_paddle_field": "x"}
+		for k, v := range fields {
+			withUnknown[k] = v
+		}
+		d := test.Sign(withUnknown)
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-key", keyPath}, strings.NewReader(d.URL), &stdout, &stderr)
+		assert.Equal(0, code, stderr.String())
+		out := stdout.String()
+		assert.Contains(out, "signature: invalid")
+		assert.Contains(out, "signature of raw fields: valid\n")
+		assert.Contains(out, `- new_paddle_field: "x" is not decoded`)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		assert := assert.New(t)
+		d := test.Sign(fields)
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"-key", keyPath}, strings.NewReader(strings.Replace(d.URL, "PLN", "USD", 1)), &stdout, &stderr)
+		assert.Equal(1, code)
+		assert.Contains(stdout.String(), "signature of raw fields: invalid")
+	})
+}
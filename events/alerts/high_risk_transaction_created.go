@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const HighRiskTransactionCreatedAlertName = "high_risk_transaction_created"
 
 // HighRiskTranasctionCreated refer to https://paddle.com/docs/reference-using-webhooks/#high_risk_transaction_created
 type HighRiskTransactionCreated struct {
+	AlertID              int                     `json:"alert_id,string,omitempty"`
 	AlertName            string                  `json:"alert_name"`
 	CaseID               int                     `json:"case_id,string"`
 	CheckoutID           string                  `json:"checkout_id"`
@@ -32,3 +35,27 @@ func (h *HighRiskTransactionCreated) Serialize() ([]byte, error) {
 func (h *HighRiskTransactionCreated) Signature() ([]byte, error) {
 	return []byte(h.PSignature), nil
 }
+
+func (h *HighRiskTransactionCreated) GetAlertName() string {
+	return h.AlertName
+}
+
+func (h *HighRiskTransactionCreated) GetAlertID() (int, bool) {
+	return h.AlertID, h.AlertID != 0
+}
+
+func (h *HighRiskTransactionCreated) GetEventTime() time.Time {
+	return h.EventTime.TimeOrZero()
+}
+
+func (h *HighRiskTransactionCreated) GetPassthrough() string {
+	return h.Passthrough
+}
+
+func (h *HighRiskTransactionCreated) GetEmail() string {
+	return h.CustomerEmailAddress
+}
+
+func (h *HighRiskTransactionCreated) GetCheckoutID() string {
+	return h.CheckoutID
+}
@@ -17,6 +17,7 @@ func subscriptionHighRiskTransactionCreatedData() struct {
 	hrtc HighRiskTransactionCreated
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":               "1730007919",
 		"alert_name":             "high_risk_transaction_created",
 		"case_id":                "1",
 		"checkout_id":            "1-c8a82616c183ad6-377f00add1",
@@ -33,6 +34,7 @@ func subscriptionHighRiskTransactionCreatedData() struct {
 	var mc types.MarketingConsent
 	mc.UnmarshalText([]byte(d.M["marketing_consent"]))
 	hrtc := HighRiskTransactionCreated{
+		AlertID:              int(test.IntFromString(d.M["alert_id"])),
 		AlertName:            d.M["alert_name"],
 		CaseID:               int(test.IntFromString(d.M["case_id"])),
 		CheckoutID:           d.M["checkout_id"],
@@ -83,6 +85,18 @@ func TestHighRiskTransactionCreated(t *testing.T) {
 		}).Verify(&data.hrtc))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.hrtc.GetAlertName())
+		alertID, ok := data.hrtc.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.hrtc.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.hrtc.GetPassthrough())
+		assert.Equal(data.d.M["customer_email_address"], data.hrtc.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.hrtc.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &HighRiskTransactionCreated{})
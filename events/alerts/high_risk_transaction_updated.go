@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const HighRiskTransactionUpdatedAlertName = "high_risk_transaction_updated"
 
 // HighRiskTranasctionUpdated refer to https://paddle.com/docs/reference-using-webhooks/#high_risk_transaction_updated
 type HighRiskTransactionUpdated struct {
+	AlertID              int                     `json:"alert_id,string,omitempty"`
 	AlertName            string                  `json:"alert_name"`
 	CaseID               int                     `json:"case_id,string"`
 	CheckoutID           string                  `json:"checkout_id"`
@@ -32,3 +35,27 @@ func (h *HighRiskTransactionUpdated) Serialize() ([]byte, error) {
 func (h *HighRiskTransactionUpdated) Signature() ([]byte, error) {
 	return []byte(h.PSignature), nil
 }
+
+func (h *HighRiskTransactionUpdated) GetAlertName() string {
+	return h.AlertName
+}
+
+func (h *HighRiskTransactionUpdated) GetAlertID() (int, bool) {
+	return h.AlertID, h.AlertID != 0
+}
+
+func (h *HighRiskTransactionUpdated) GetEventTime() time.Time {
+	return h.EventTime.TimeOrZero()
+}
+
+func (h *HighRiskTransactionUpdated) GetPassthrough() string {
+	return h.Passthrough
+}
+
+func (h *HighRiskTransactionUpdated) GetEmail() string {
+	return h.CustomerEmailAddress
+}
+
+func (h *HighRiskTransactionUpdated) GetCheckoutID() string {
+	return h.CheckoutID
+}
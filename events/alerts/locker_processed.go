@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -9,6 +11,7 @@ const LockerProcessedAlertName = "locker_processed"
 
 // LockerProcessed refer to https://paddle.com/docs/reference-using-webhooks/#locker_processed
 type LockerProcessed struct {
+	AlertID          int                     `json:"alert_id,string,omitempty"`
 	AlertName        string                  `json:"alert_name"`
 	CheckoutID       string                  `json:"checkout_id"`
 	CheckoutRecovery int                     `json:"checkout_recovery,string"`
@@ -32,3 +35,27 @@ func (l *LockerProcessed) Serialize() ([]byte, error) {
 func (l *LockerProcessed) Signature() ([]byte, error) {
 	return []byte(l.PSignature), nil
 }
+
+func (l *LockerProcessed) GetAlertName() string {
+	return l.AlertName
+}
+
+func (l *LockerProcessed) GetAlertID() (int, bool) {
+	return l.AlertID, l.AlertID != 0
+}
+
+func (l *LockerProcessed) GetEventTime() time.Time {
+	return l.EventTime.TimeOrZero()
+}
+
+func (l *LockerProcessed) GetPassthrough() string {
+	return ""
+}
+
+func (l *LockerProcessed) GetEmail() string {
+	return l.Email
+}
+
+func (l *LockerProcessed) GetCheckoutID() string {
+	return l.CheckoutID
+}
@@ -17,6 +17,7 @@ func lockerProcessedData() struct {
 	lp LockerProcessed
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":          "1730023757",
 		"alert_name":        "locker_processed",
 		"checkout_id":       "1-c8a82616c183ad6-377f00add1",
 		"checkout_recovery": "1",
@@ -34,6 +35,7 @@ func lockerProcessedData() struct {
 	var mc types.MarketingConsent
 	mc.UnmarshalText([]byte(d.M["marketing_consent"]))
 	lp := LockerProcessed{
+		AlertID:          int(test.IntFromString(d.M["alert_id"])),
 		AlertName:        d.M["alert_name"],
 		CheckoutID:       d.M["checkout_id"],
 		CheckoutRecovery: int(test.IntFromString(d.M["checkout_recovery"])),
@@ -85,6 +87,18 @@ func TestLockerProcessed(t *testing.T) {
 		}).Verify(&data.lp))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.lp.GetAlertName())
+		alertID, ok := data.lp.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.lp.GetEventTime())
+		assert.Empty(data.lp.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.lp.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.lp.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &LockerProcessed{})
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
@@ -58,6 +59,7 @@ func (n AudienceMemberProducts) String() string {
 
 // NewAudienceMember refer to https://paddle.com/docs/reference-using-webhooks/#new_audience_member
 type NewAudienceMember struct {
+	AlertID          int                     `json:"alert_id,string,omitempty"`
 	AlertName        string                  `json:"alert_name"`
 	CreatedAt        *types.Datetime         `json:"created_at,string"`
 	Email            string                  `json:"email"`
@@ -77,3 +79,27 @@ func (m *NewAudienceMember) Serialize() ([]byte, error) {
 func (m *NewAudienceMember) Signature() ([]byte, error) {
 	return []byte(m.PSignature), nil
 }
+
+func (m *NewAudienceMember) GetAlertName() string {
+	return m.AlertName
+}
+
+func (m *NewAudienceMember) GetAlertID() (int, bool) {
+	return m.AlertID, m.AlertID != 0
+}
+
+func (m *NewAudienceMember) GetEventTime() time.Time {
+	return m.EventTime.TimeOrZero()
+}
+
+func (m *NewAudienceMember) GetPassthrough() string {
+	return ""
+}
+
+func (m *NewAudienceMember) GetEmail() string {
+	return m.Email
+}
+
+func (m *NewAudienceMember) GetCheckoutID() string {
+	return ""
+}
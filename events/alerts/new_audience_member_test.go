@@ -17,6 +17,7 @@ func subscriptionNewAudienceMemberData() struct {
 	nam NewAudienceMember
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":          "1730031676",
 		"alert_name":        "new_audience_member",
 		"created_at":        "2019-04-15 07:37:53",
 		"email":             "jan@kowalski.net",
@@ -32,6 +33,7 @@ func subscriptionNewAudienceMemberData() struct {
 	var mc types.MarketingConsent
 	mc.UnmarshalText([]byte(d.M["marketing_consent"]))
 	nam := NewAudienceMember{
+		AlertID:          int(test.IntFromString(d.M["alert_id"])),
 		AlertName:        d.M["alert_name"],
 		CreatedAt:        &types.Datetime{test.ParseTime(types.DatetimeFormat, d.M["created_at"])},
 		Email:            d.M["email"],
@@ -79,6 +81,18 @@ func TestNewAudienceMember(t *testing.T) {
 		}).Verify(&data.nam))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.nam.GetAlertName())
+		alertID, ok := data.nam.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.nam.GetEventTime())
+		assert.Empty(data.nam.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.nam.GetEmail())
+		assert.Empty(data.nam.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &NewAudienceMember{})
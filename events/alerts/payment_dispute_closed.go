@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const PaymentDisputeClosedAlertName = "payment_dispute_closed"
 
 // PaymentDisputeClosed refer to https://paddle.com/docs/reference-using-webhooks/#payment_dispute_closed
 type PaymentDisputeClosed struct {
+	AlertID          int                     `json:"alert_id,string,omitempty"`
 	AlertName        string                  `json:"alert_name"`
 	Amount           *decimal.Decimal        `json:"amount,string"`
 	CheckoutID       string                  `json:"checkout_id"`
@@ -31,3 +34,27 @@ func (p *PaymentDisputeClosed) Serialize() ([]byte, error) {
 func (p *PaymentDisputeClosed) Signature() ([]byte, error) {
 	return []byte(p.PSignature), nil
 }
+
+func (p *PaymentDisputeClosed) GetAlertName() string {
+	return p.AlertName
+}
+
+func (p *PaymentDisputeClosed) GetAlertID() (int, bool) {
+	return p.AlertID, p.AlertID != 0
+}
+
+func (p *PaymentDisputeClosed) GetEventTime() time.Time {
+	return p.EventTime.TimeOrZero()
+}
+
+func (p *PaymentDisputeClosed) GetPassthrough() string {
+	return p.Passthrough
+}
+
+func (p *PaymentDisputeClosed) GetEmail() string {
+	return p.Email
+}
+
+func (p *PaymentDisputeClosed) GetCheckoutID() string {
+	return p.CheckoutID
+}
@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const PaymentDisputeCreatedAlertName = "payment_dispute_created"
 
 // PaymentDisputeCreated  refer to https://paddle.com/docs/reference-using-webhooks/#payment_dispute_created
 type PaymentDisputeCreated struct {
+	AlertID          int                     `json:"alert_id,string,omitempty"`
 	AlertName        string                  `json:"alert_name"`
 	Amount           *decimal.Decimal        `json:"amount,string"`
 	CheckoutID       string                  `json:"checkout_id"`
@@ -31,3 +34,27 @@ func (p *PaymentDisputeCreated) Serialize() ([]byte, error) {
 func (p *PaymentDisputeCreated) Signature() ([]byte, error) {
 	return []byte(p.PSignature), nil
 }
+
+func (p *PaymentDisputeCreated) GetAlertName() string {
+	return p.AlertName
+}
+
+func (p *PaymentDisputeCreated) GetAlertID() (int, bool) {
+	return p.AlertID, p.AlertID != 0
+}
+
+func (p *PaymentDisputeCreated) GetEventTime() time.Time {
+	return p.EventTime.TimeOrZero()
+}
+
+func (p *PaymentDisputeCreated) GetPassthrough() string {
+	return p.Passthrough
+}
+
+func (p *PaymentDisputeCreated) GetEmail() string {
+	return p.Email
+}
+
+func (p *PaymentDisputeCreated) GetCheckoutID() string {
+	return p.CheckoutID
+}
@@ -17,6 +17,7 @@ func subscriptionPaymentDisputeCreatedData() struct {
 	pdc PaymentDisputeCreated
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":          "1730047514",
 		"alert_name":        "payment_dispute_created",
 		"amount":            "1.23",
 		"checkout_id":       "1-c8a82616c183ad6-377f00add1",
@@ -32,6 +33,7 @@ func subscriptionPaymentDisputeCreatedData() struct {
 	var mc types.MarketingConsent
 	mc.UnmarshalText([]byte(d.M["marketing_consent"]))
 	pdc := PaymentDisputeCreated{
+		AlertID:          int(test.IntFromString(d.M["alert_id"])),
 		AlertName:        d.M["alert_name"],
 		Amount:           test.DecimalFromString(d.M["amount"]),
 		CheckoutID:       d.M["checkout_id"],
@@ -81,6 +83,18 @@ func TestPaymentDisputeCreated(t *testing.T) {
 		}).Verify(&data.pdc))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.pdc.GetAlertName())
+		alertID, ok := data.pdc.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.pdc.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.pdc.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.pdc.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.pdc.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &PaymentDisputeCreated{})
@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const PaymentRefundedAlertName = "payment_refunded"
 
 // PaymentRefunded refer to https://paddle.com/docs/reference-using-webhooks/#payment_refunded
 type PaymentRefunded struct {
+	AlertID                 int                     `json:"alert_id,string,omitempty"`
 	AlertName               string                  `json:"alert_name"`
 	Amount                  *decimal.Decimal        `json:"amount,string"`
 	BalanceCurrency         string                  `json:"balance_currency"`
@@ -40,3 +43,27 @@ func (s *PaymentRefunded) Serialize() ([]byte, error) {
 func (s *PaymentRefunded) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *PaymentRefunded) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *PaymentRefunded) GetAlertID() (int, bool) {
+	return s.AlertID, s.AlertID != 0
+}
+
+func (s *PaymentRefunded) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *PaymentRefunded) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *PaymentRefunded) GetEmail() string {
+	return s.Email
+}
+
+func (s *PaymentRefunded) GetCheckoutID() string {
+	return s.CheckoutID
+}
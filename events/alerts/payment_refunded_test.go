@@ -17,6 +17,7 @@ func subscriptionPaymentRefundedData() struct {
 	spr PaymentRefunded
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":                  "1730055433",
 		"alert_name":                "subscription_payment_succeeded",
 		"amount":                    "1.23",
 		"balance_currency":          "PLN",
@@ -41,6 +42,7 @@ func subscriptionPaymentRefundedData() struct {
 	var mc types.MarketingConsent
 	mc.UnmarshalText([]byte(d.M["marketing_consent"]))
 	spr := PaymentRefunded{
+		AlertID:                 int(test.IntFromString(d.M["alert_id"])),
 		AlertName:               d.M["alert_name"],
 		Amount:                  test.DecimalFromString(d.M["amount"]),
 		BalanceCurrency:         d.M["balance_currency"],
@@ -99,6 +101,18 @@ func TestPaymentRefunded(t *testing.T) {
 		}).Verify(&data.spr))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.spr.GetAlertName())
+		alertID, ok := data.spr.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.spr.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.spr.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.spr.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.spr.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &PaymentRefunded{})
@@ -2,6 +2,7 @@ package alerts
 
 import (
 	"net"
+	"time"
 
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
@@ -12,6 +13,7 @@ const PaymentSucceededAlertName = "payment_succeeded"
 
 // PaymentSucceeded refer to https://paddle.com/docs/reference-using-webhooks/#payment_succeeded
 type PaymentSucceeded struct {
+	AlertID           int                     `json:"alert_id,string,omitempty"`
 	AlertName         string                  `json:"alert_name"`
 	BalanceCurrency   string                  `json:"balance_currency"`
 	BalanceEarnings   *decimal.Decimal        `json:"balance_earnings,string"`
@@ -49,3 +51,27 @@ func (s *PaymentSucceeded) Serialize() ([]byte, error) {
 func (s *PaymentSucceeded) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *PaymentSucceeded) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *PaymentSucceeded) GetAlertID() (int, bool) {
+	return s.AlertID, s.AlertID != 0
+}
+
+func (s *PaymentSucceeded) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *PaymentSucceeded) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *PaymentSucceeded) GetEmail() string {
+	return s.Email
+}
+
+func (s *PaymentSucceeded) GetCheckoutID() string {
+	return s.CheckoutID
+}
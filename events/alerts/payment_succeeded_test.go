@@ -18,6 +18,7 @@ func subscriptionPaymentSucceededData() struct {
 	sps PaymentSucceeded
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":            "1730063352",
 		"alert_name":          "payment_succeeded",
 		"balance_currency":    "PLN",
 		"balance_earnings":    "1.23",
@@ -50,6 +51,7 @@ func subscriptionPaymentSucceededData() struct {
 	var mc types.MarketingConsent
 	mc.UnmarshalText([]byte(d.M["marketing_consent"]))
 	sps := PaymentSucceeded{
+		AlertID:           int(test.IntFromString(d.M["alert_id"])),
 		AlertName:         d.M["alert_name"],
 		BalanceCurrency:   d.M["balance_currency"],
 		BalanceEarnings:   test.DecimalFromString(d.M["balance_earnings"]),
@@ -115,6 +117,18 @@ func TestPaymentSucceeded(t *testing.T) {
 		}).Verify(&data.sps))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.sps.GetAlertName())
+		alertID, ok := data.sps.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.sps.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.sps.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.sps.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.sps.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &PaymentSucceeded{})
@@ -0,0 +1,22 @@
+package alerts
+
+import "github.com/dennor/go-paddle/events"
+
+func init() {
+	Register(events.DefaultRegistry)
+}
+
+// Register adds all alerts from this package to registry.
+func Register(r *events.Registry) {
+	r.Register(HighRiskTransactionCreatedAlertName, func() events.Event { return new(HighRiskTransactionCreated) })
+	r.Register(HighRiskTransactionUpdatedAlertName, func() events.Event { return new(HighRiskTransactionUpdated) })
+	r.Register(LockerProcessedAlertName, func() events.Event { return new(LockerProcessed) })
+	r.Register(NewAudienceMemberAlertName, func() events.Event { return new(NewAudienceMember) })
+	r.Register(PaymentDisputeClosedAlertName, func() events.Event { return new(PaymentDisputeClosed) })
+	r.Register(PaymentDisputeCreatedAlertName, func() events.Event { return new(PaymentDisputeCreated) })
+	r.Register(PaymentRefundedAlertName, func() events.Event { return new(PaymentRefunded) })
+	r.Register(PaymentSucceededAlertName, func() events.Event { return new(PaymentSucceeded) })
+	r.Register(TransferCreatedAlertName, func() events.Event { return new(TransferCreated) })
+	r.Register(TransferPaidAlertName, func() events.Event { return new(TransferPaid) })
+	r.Register(UpdateAudienceMemberAlertName, func() events.Event { return new(UpdateAudienceMember) })
+}
@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const TransferCreatedAlertName = "transfer_created"
 
 // TransferCreated refer to https://paddle.com/docs/reference-using-webhooks/#transfer_created
 type TransferCreated struct {
+	AlertID    int              `json:"alert_id,string,omitempty"`
 	AlertName  string           `json:"alert_name"`
 	Amount     *decimal.Decimal `json:"amount,string"`
 	Currency   string           `json:"currency"`
@@ -26,3 +29,27 @@ func (t *TransferCreated) Serialize() ([]byte, error) {
 func (t *TransferCreated) Signature() ([]byte, error) {
 	return []byte(t.PSignature), nil
 }
+
+func (t *TransferCreated) GetAlertName() string {
+	return t.AlertName
+}
+
+func (t *TransferCreated) GetAlertID() (int, bool) {
+	return t.AlertID, t.AlertID != 0
+}
+
+func (t *TransferCreated) GetEventTime() time.Time {
+	return t.EventTime.TimeOrZero()
+}
+
+func (t *TransferCreated) GetPassthrough() string {
+	return ""
+}
+
+func (t *TransferCreated) GetEmail() string {
+	return ""
+}
+
+func (t *TransferCreated) GetCheckoutID() string {
+	return ""
+}
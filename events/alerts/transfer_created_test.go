@@ -17,6 +17,7 @@ func subscriptionTransferCreatedData() struct {
 	tc TransferCreated
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":   "1730071271",
 		"alert_name": "transfer_paid",
 		"amount":     "1.23",
 		"currency":   "PLN",
@@ -25,6 +26,7 @@ func subscriptionTransferCreatedData() struct {
 		"status":     "closed",
 	})
 	tc := TransferCreated{
+		AlertID:    int(test.IntFromString(d.M["alert_id"])),
 		AlertName:  d.M["alert_name"],
 		Amount:     test.DecimalFromString(d.M["amount"]),
 		Currency:   d.M["currency"],
@@ -69,6 +71,18 @@ func TestTransferCreated(t *testing.T) {
 		}).Verify(&data.tc))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.tc.GetAlertName())
+		alertID, ok := data.tc.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.tc.GetEventTime())
+		assert.Empty(data.tc.GetPassthrough())
+		assert.Empty(data.tc.GetEmail())
+		assert.Empty(data.tc.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &TransferCreated{})
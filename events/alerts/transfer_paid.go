@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 	"github.com/shopspring/decimal"
@@ -10,6 +12,7 @@ const TransferPaidAlertName = "transfer_paid"
 
 // TransferPaid refer to https://paddle.com/docs/reference-using-webhooks/#transfer_paid
 type TransferPaid struct {
+	AlertID    int              `json:"alert_id,string,omitempty"`
 	AlertName  string           `json:"alert_name"`
 	Amount     *decimal.Decimal `json:"amount,string"`
 	Currency   string           `json:"currency"`
@@ -26,3 +29,27 @@ func (t *TransferPaid) Serialize() ([]byte, error) {
 func (t *TransferPaid) Signature() ([]byte, error) {
 	return []byte(t.PSignature), nil
 }
+
+func (t *TransferPaid) GetAlertName() string {
+	return t.AlertName
+}
+
+func (t *TransferPaid) GetAlertID() (int, bool) {
+	return t.AlertID, t.AlertID != 0
+}
+
+func (t *TransferPaid) GetEventTime() time.Time {
+	return t.EventTime.TimeOrZero()
+}
+
+func (t *TransferPaid) GetPassthrough() string {
+	return ""
+}
+
+func (t *TransferPaid) GetEmail() string {
+	return ""
+}
+
+func (t *TransferPaid) GetCheckoutID() string {
+	return ""
+}
@@ -17,6 +17,7 @@ func subscriptionTransferPaidData() struct {
 	tp TransferPaid
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":   "1730079190",
 		"alert_name": "transfer_paid",
 		"amount":     "1.23",
 		"currency":   "PLN",
@@ -25,6 +26,7 @@ func subscriptionTransferPaidData() struct {
 		"status":     "closed",
 	})
 	tp := TransferPaid{
+		AlertID:    int(test.IntFromString(d.M["alert_id"])),
 		AlertName:  d.M["alert_name"],
 		Amount:     test.DecimalFromString(d.M["amount"]),
 		Currency:   d.M["currency"],
@@ -69,6 +71,37 @@ func TestTransferPaid(t *testing.T) {
 		}).Verify(&data.tp))
 	})
 
+	t.Run("VerifyWithoutAlertID", func(t *testing.T) {
+		assert := assert.New(t)
+		d := test.Sign(map[string]string{
+			"alert_name": "transfer_paid",
+			"amount":     "1.23",
+			"currency":   "PLN",
+			"event_time": "2019-04-15 07:37:53",
+			"payout_id":  "2",
+			"status":     "closed",
+		})
+		var actual TransferPaid
+		assert.NoError(urldecode.Unmarshal([]byte(d.URL), &actual))
+		assert.NoError(events.RSAVerifier(signature.RSA{
+			PublicKey: &test.Key.PublicKey,
+		}).Verify(&actual))
+		_, ok := actual.GetAlertID()
+		assert.False(ok)
+	})
+
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.tp.GetAlertName())
+		alertID, ok := data.tp.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.tp.GetEventTime())
+		assert.Empty(data.tp.GetPassthrough())
+		assert.Empty(data.tp.GetEmail())
+		assert.Empty(data.tp.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &TransferPaid{})
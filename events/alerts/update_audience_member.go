@@ -1,6 +1,8 @@
 package alerts
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -9,6 +11,7 @@ const UpdateAudienceMemberAlertName = "update_audience_member"
 
 // UpdateAudienceMember refer to https://paddle.com/docs/reference-using-webhooks/#update_audience_member
 type UpdateAudienceMember struct {
+	AlertID             int                     `json:"alert_id,string,omitempty"`
 	AlertName           string                  `json:"alert_name"`
 	EventTime           *types.Datetime         `json:"event_time,string"`
 	NewCustomerEmail    string                  `json:"new_customer_email"`
@@ -29,3 +32,27 @@ func (u *UpdateAudienceMember) Serialize() ([]byte, error) {
 func (u *UpdateAudienceMember) Signature() ([]byte, error) {
 	return []byte(u.PSignature), nil
 }
+
+func (u *UpdateAudienceMember) GetAlertName() string {
+	return u.AlertName
+}
+
+func (u *UpdateAudienceMember) GetAlertID() (int, bool) {
+	return u.AlertID, u.AlertID != 0
+}
+
+func (u *UpdateAudienceMember) GetEventTime() time.Time {
+	return u.EventTime.TimeOrZero()
+}
+
+func (u *UpdateAudienceMember) GetPassthrough() string {
+	return ""
+}
+
+func (u *UpdateAudienceMember) GetEmail() string {
+	return u.NewCustomerEmail
+}
+
+func (u *UpdateAudienceMember) GetCheckoutID() string {
+	return ""
+}
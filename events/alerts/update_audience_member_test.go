@@ -17,6 +17,7 @@ func subscriptionUpdateAudienceMemberData() struct {
 	uam UpdateAudienceMember
 } {
 	d := test.Sign(map[string]string{
+		"alert_id":              "1730087109",
 		"alert_name":            "new_audience_member",
 		"event_time":            "2019-04-15 07:37:53",
 		"new_customer_email":    "jan@kowalski.net",
@@ -35,6 +36,7 @@ func subscriptionUpdateAudienceMemberData() struct {
 	var omc types.MarketingConsent
 	omc.UnmarshalText([]byte(d.M["old_marketing_consent"]))
 	uam := UpdateAudienceMember{
+		AlertID:             int(test.IntFromString(d.M["alert_id"])),
 		AlertName:           d.M["alert_name"],
 		EventTime:           &types.Datetime{test.ParseTime(types.DatetimeFormat, d.M["event_time"])},
 		NewCustomerEmail:    d.M["new_customer_email"],
@@ -83,6 +85,18 @@ func TestUpdateAudienceMember(t *testing.T) {
 		}).Verify(&data.uam))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.uam.GetAlertName())
+		alertID, ok := data.uam.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.uam.GetEventTime())
+		assert.Empty(data.uam.GetPassthrough())
+		assert.Equal(data.d.M["new_customer_email"], data.uam.GetEmail())
+		assert.Empty(data.uam.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &UpdateAudienceMember{})
@@ -0,0 +1,126 @@
+// Package billing implements paddle billing (v2) webhook notifications.
+package billing
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Event is implemented by every billing notification.
+type Event interface {
+	GetEventID() string
+	GetEventType() string
+	GetNotificationID() string
+	GetOccurredAt() time.Time
+}
+
+// Verifier verifies raw notification body against Paddle-Signature header value.
+type Verifier interface {
+	Verify(body []byte, header string) error
+}
+
+// Envelope holds fields common to all billing notifications.
+type Envelope struct {
+	EventID        string    `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	NotificationID string    `json:"notification_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+func (e *Envelope) GetEventID() string {
+	return e.EventID
+}
+
+func (e *Envelope) GetEventType() string {
+	return e.EventType
+}
+
+func (e *Envelope) GetNotificationID() string {
+	return e.NotificationID
+}
+
+func (e *Envelope) GetOccurredAt() time.Time {
+	return e.OccurredAt
+}
+
+// Entity returns entity part of event type, for example transaction
+// for transaction.completed.
+func (e *Envelope) Entity() string {
+	return Entity(e.EventType)
+}
+
+// Entity returns entity part of event type.
+func Entity(eventType string) string {
+	if i := strings.IndexByte(eventType, '.'); i >= 0 {
+		return eventType[:i]
+	}
+	return eventType
+}
+
+// Notification is a billing notification of entity not known to this package.
+type Notification struct {
+	Envelope
+	Data json.RawMessage `json:"data"`
+}
+
+// Unmarshal decodes billing notification into typed struct
+// selected by entity in event_type. Notifications for unknown
+// entities are returned as *Notification.
+func Unmarshal(b []byte) (Event, error) {
+	var env Envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, err
+	}
+	var e Event
+	switch env.Entity() {
+	case TransactionEntity:
+		e = new(TransactionNotification)
+	case SubscriptionEntity:
+		e = new(SubscriptionNotification)
+	case CustomerEntity:
+		e = new(CustomerNotification)
+	default:
+		e = new(Notification)
+	}
+	return e, json.Unmarshal(b, e)
+}
+
+// Money is an amount in lowest denomination of currency.
+type Money struct {
+	Amount       string `json:"amount"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+// Period is a time range, for example billing period.
+type Period struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// Duration describes billing cycle or trial period.
+type Duration struct {
+	Interval  string `json:"interval"`
+	Frequency int    `json:"frequency"`
+}
+
+// BillingDetails are present for manually collected entities.
+type BillingDetails struct {
+	EnableCheckout        bool     `json:"enable_checkout"`
+	PurchaseOrderNumber   string   `json:"purchase_order_number"`
+	AdditionalInformation *string  `json:"additional_information"`
+	PaymentTerms          Duration `json:"payment_terms"`
+}
+
+// Price is a price entity embedded in items.
+type Price struct {
+	ID           string    `json:"id"`
+	ProductID    string    `json:"product_id"`
+	Description  string    `json:"description"`
+	Name         *string   `json:"name"`
+	BillingCycle *Duration `json:"billing_cycle"`
+	TrialPeriod  *Duration `json:"trial_period"`
+	TaxMode      string    `json:"tax_mode"`
+	UnitPrice    Money     `json:"unit_price"`
+	Status       string    `json:"status"`
+}
@@ -0,0 +1,199 @@
+package billing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transactionCompletedJSON = `{
+  "event_id": "evt_01h04vsc0qhwtsbsxh3422wjs4",
+  "event_type": "transaction.completed",
+  "occurred_at": "2023-04-17T12:36:23.578938Z",
+  "notification_id": "ntf_01h04vsc3dnnbg1qgg1t6f5eyr",
+  "data": {
+    "id": "txn_01h04vsbhqc62t8hmd4z3b578c",
+    "status": "completed",
+    "customer_id": "ctm_01h04vsc0qhwtsbsxh3422wjs4",
+    "address_id": "add_01h04vsc0qhwtsbsxh3422wjs4",
+    "business_id": null,
+    "custom_data": {"order": 42},
+    "currency_code": "USD",
+    "origin": "web",
+    "subscription_id": "sub_01h04vsc0qhwtsbsxh3422wjs4",
+    "invoice_id": null,
+    "invoice_number": null,
+    "collection_mode": "automatic",
+    "discount_id": null,
+    "billing_details": null,
+    "billing_period": {
+      "starts_at": "2023-04-17T12:36:20.000000Z",
+      "ends_at": "2023-05-17T12:36:20.000000Z"
+    },
+    "items": [
+      {
+        "price_id": "pri_01gsz8x8sawmvhz1pv30nge1ke",
+        "price": {
+          "id": "pri_01gsz8x8sawmvhz1pv30nge1ke",
+          "product_id": "pro_01gsz4t5hdjse780zja8vvr7jg",
+          "description": "Monthly",
+          "billing_cycle": {"interval": "month", "frequency": 1},
+          "trial_period": null,
+          "tax_mode": "account_setting",
+          "unit_price": {"amount": "3000", "currency_code": "USD"},
+          "status": "active"
+        },
+        "quantity": 10
+      }
+    ],
+    "details": {
+      "totals": {
+        "subtotal": "30000",
+        "discount": "0",
+        "tax": "0",
+        "total": "30000",
+        "credit": "0",
+        "balance": "0",
+        "grand_total": "30000",
+        "fee": "1550",
+        "earnings": "28450",
+        "currency_code": "USD"
+      }
+    },
+    "payments": [],
+    "checkout": {"url": "https://example.com/pay?_ptxn=txn_01h04vsbhqc62t8hmd4z3b578c"},
+    "created_at": "2023-04-17T12:35:58.749218Z",
+    "updated_at": "2023-04-17T12:36:23.451436Z",
+    "billed_at": "2023-04-17T12:36:20.914217Z"
+  }
+}`
+
+const subscriptionActivatedJSON = `{
+  "event_id": "evt_01h04vsc1y6k4f6r1s1kc29ncr",
+  "event_type": "subscription.activated",
+  "occurred_at": "2023-04-17T12:36:24.000000Z",
+  "notification_id": "ntf_01h04vsc3dnnbg1qgg1t6f5eys",
+  "data": {
+    "id": "sub_01h04vsc0qhwtsbsxh3422wjs4",
+    "status": "active",
+    "customer_id": "ctm_01h04vsc0qhwtsbsxh3422wjs4",
+    "address_id": "add_01h04vsc0qhwtsbsxh3422wjs4",
+    "currency_code": "USD",
+    "created_at": "2023-04-17T12:36:22.000000Z",
+    "updated_at": "2023-04-17T12:36:22.000000Z",
+    "started_at": "2023-04-17T12:36:20.000000Z",
+    "next_billed_at": "2023-05-17T12:36:20.000000Z",
+    "collection_mode": "automatic",
+    "current_billing_period": {
+      "starts_at": "2023-04-17T12:36:20.000000Z",
+      "ends_at": "2023-05-17T12:36:20.000000Z"
+    },
+    "billing_cycle": {"interval": "month", "frequency": 1},
+    "scheduled_change": null,
+    "items": [
+      {
+        "status": "active",
+        "quantity": 10,
+        "recurring": true,
+        "created_at": "2023-04-17T12:36:22.000000Z",
+        "updated_at": "2023-04-17T12:36:22.000000Z",
+        "price": {
+          "id": "pri_01gsz8x8sawmvhz1pv30nge1ke",
+          "product_id": "pro_01gsz4t5hdjse780zja8vvr7jg",
+          "unit_price": {"amount": "3000", "currency_code": "USD"}
+        }
+      }
+    ],
+    "custom_data": null
+  }
+}`
+
+const customerUpdatedJSON = `{
+  "event_id": "evt_01h04vsc1y6k4f6r1s1kc29nct",
+  "event_type": "customer.updated",
+  "occurred_at": "2023-04-17T12:36:25.000000Z",
+  "notification_id": "ntf_01h04vsc3dnnbg1qgg1t6f5eyt",
+  "data": {
+    "id": "ctm_01h04vsc0qhwtsbsxh3422wjs4",
+    "name": "Jo Brown",
+    "email": "jo@example.com",
+    "marketing_consent": true,
+    "status": "active",
+    "custom_data": null,
+    "locale": "en",
+    "created_at": "2023-04-17T12:36:22.000000Z",
+    "updated_at": "2023-04-17T12:36:25.000000Z"
+  }
+}`
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("Transaction", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		e, err := Unmarshal([]byte(transactionCompletedJSON))
+		require.NoError(err)
+		tn, ok := e.(*TransactionNotification)
+		require.True(ok)
+		assert.Equal(TransactionCompletedEventType, tn.GetEventType())
+		assert.Equal("evt_01h04vsc0qhwtsbsxh3422wjs4", tn.GetEventID())
+		assert.Equal("ntf_01h04vsc3dnnbg1qgg1t6f5eyr", tn.GetNotificationID())
+		assert.Equal(time.Date(2023, 4, 17, 12, 36, 23, 578938000, time.UTC), tn.GetOccurredAt())
+		assert.Equal("txn_01h04vsbhqc62t8hmd4z3b578c", tn.Data.ID)
+		assert.Equal("sub_01h04vsc0qhwtsbsxh3422wjs4", *tn.Data.SubscriptionID)
+		assert.Nil(tn.Data.BusinessID)
+		assert.JSONEq(`{"order": 42}`, string(tn.Data.CustomData))
+		require.Len(tn.Data.Items, 1)
+		assert.Equal(10, tn.Data.Items[0].Quantity)
+		assert.Equal(Money{Amount: "3000", CurrencyCode: "USD"}, tn.Data.Items[0].Price.UnitPrice)
+		assert.Equal("30000", tn.Data.Details.Totals.GrandTotal)
+	})
+
+	t.Run("Subscription", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		e, err := Unmarshal([]byte(subscriptionActivatedJSON))
+		require.NoError(err)
+		sn, ok := e.(*SubscriptionNotification)
+		require.True(ok)
+		assert.Equal(SubscriptionActivatedEventType, sn.GetEventType())
+		assert.Equal(SubscriptionEntity, sn.Entity())
+		assert.Equal("active", sn.Data.Status)
+		assert.Equal(Duration{Interval: "month", Frequency: 1}, sn.Data.BillingCycle)
+		assert.Nil(sn.Data.ScheduledChange)
+		assert.Nil(sn.Data.PausedAt)
+	})
+
+	t.Run("Customer", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		e, err := Unmarshal([]byte(customerUpdatedJSON))
+		require.NoError(err)
+		cn, ok := e.(*CustomerNotification)
+		require.True(ok)
+		assert.Equal(CustomerUpdatedEventType, cn.GetEventType())
+		assert.Equal("jo@example.com", cn.Data.Email)
+		assert.True(cn.Data.MarketingConsent)
+	})
+
+	t.Run("UnknownEntity", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		e, err := Unmarshal([]byte(`{"event_id":"evt_1","event_type":"adjustment.created","data":{"id":"adj_1"}}`))
+		require.NoError(err)
+		n, ok := e.(*Notification)
+		require.True(ok)
+		assert.Equal("adjustment", n.Entity())
+		var data map[string]string
+		require.NoError(json.Unmarshal(n.Data, &data))
+		assert.Equal("adj_1", data["id"])
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := Unmarshal([]byte(`not json`))
+		assert.Error(err)
+	})
+}
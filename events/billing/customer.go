@@ -0,0 +1,33 @@
+package billing
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	CustomerEntity = "customer"
+
+	CustomerCreatedEventType  = "customer.created"
+	CustomerImportedEventType = "customer.imported"
+	CustomerUpdatedEventType  = "customer.updated"
+)
+
+// Customer refer to https://developer.paddle.com/api-reference/customers/overview
+type Customer struct {
+	ID               string          `json:"id"`
+	Name             *string         `json:"name"`
+	Email            string          `json:"email"`
+	MarketingConsent bool            `json:"marketing_consent"`
+	Status           string          `json:"status"`
+	CustomData       json.RawMessage `json:"custom_data"`
+	Locale           string          `json:"locale"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// CustomerNotification is sent for customer.* event types.
+type CustomerNotification struct {
+	Envelope
+	Data Customer `json:"data"`
+}
@@ -0,0 +1,75 @@
+package billing
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	SubscriptionEntity = "subscription"
+
+	SubscriptionActivatedEventType = "subscription.activated"
+	SubscriptionCanceledEventType  = "subscription.canceled"
+	SubscriptionCreatedEventType   = "subscription.created"
+	SubscriptionImportedEventType  = "subscription.imported"
+	SubscriptionPastDueEventType   = "subscription.past_due"
+	SubscriptionPausedEventType    = "subscription.paused"
+	SubscriptionResumedEventType   = "subscription.resumed"
+	SubscriptionTrialingEventType  = "subscription.trialing"
+	SubscriptionUpdatedEventType   = "subscription.updated"
+)
+
+type SubscriptionItem struct {
+	Status             string     `json:"status"`
+	Quantity           int        `json:"quantity"`
+	Recurring          bool       `json:"recurring"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	PreviouslyBilledAt *time.Time `json:"previously_billed_at"`
+	NextBilledAt       *time.Time `json:"next_billed_at"`
+	TrialDates         *Period    `json:"trial_dates"`
+	Price              Price      `json:"price"`
+}
+
+type ScheduledChange struct {
+	Action      string     `json:"action"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	ResumeAt    *time.Time `json:"resume_at"`
+}
+
+type SubscriptionDiscount struct {
+	ID       string     `json:"id"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
+
+// Subscription refer to https://developer.paddle.com/api-reference/subscriptions/overview
+type Subscription struct {
+	ID                   string                `json:"id"`
+	Status               string                `json:"status"`
+	CustomerID           string                `json:"customer_id"`
+	AddressID            string                `json:"address_id"`
+	BusinessID           *string               `json:"business_id"`
+	CurrencyCode         string                `json:"currency_code"`
+	CreatedAt            time.Time             `json:"created_at"`
+	UpdatedAt            time.Time             `json:"updated_at"`
+	StartedAt            *time.Time            `json:"started_at"`
+	FirstBilledAt        *time.Time            `json:"first_billed_at"`
+	NextBilledAt         *time.Time            `json:"next_billed_at"`
+	PausedAt             *time.Time            `json:"paused_at"`
+	CanceledAt           *time.Time            `json:"canceled_at"`
+	Discount             *SubscriptionDiscount `json:"discount"`
+	CollectionMode       string                `json:"collection_mode"`
+	BillingDetails       *BillingDetails       `json:"billing_details"`
+	CurrentBillingPeriod *Period               `json:"current_billing_period"`
+	BillingCycle         Duration              `json:"billing_cycle"`
+	ScheduledChange      *ScheduledChange      `json:"scheduled_change"`
+	Items                []SubscriptionItem    `json:"items"`
+	CustomData           json.RawMessage       `json:"custom_data"`
+}
+
+// SubscriptionNotification is sent for subscription.* event types.
+type SubscriptionNotification struct {
+	Envelope
+	Data Subscription `json:"data"`
+}
@@ -0,0 +1,89 @@
+package billing
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	TransactionEntity = "transaction"
+
+	TransactionBilledEventType        = "transaction.billed"
+	TransactionCanceledEventType      = "transaction.canceled"
+	TransactionCompletedEventType     = "transaction.completed"
+	TransactionCreatedEventType       = "transaction.created"
+	TransactionPaidEventType          = "transaction.paid"
+	TransactionPastDueEventType       = "transaction.past_due"
+	TransactionPaymentFailedEventType = "transaction.payment_failed"
+	TransactionReadyEventType         = "transaction.ready"
+	TransactionUpdatedEventType       = "transaction.updated"
+)
+
+type TransactionItem struct {
+	PriceID  string `json:"price_id"`
+	Price    Price  `json:"price"`
+	Quantity int    `json:"quantity"`
+}
+
+type TransactionTotals struct {
+	Subtotal     string `json:"subtotal"`
+	Discount     string `json:"discount"`
+	Tax          string `json:"tax"`
+	Total        string `json:"total"`
+	Credit       string `json:"credit"`
+	Balance      string `json:"balance"`
+	GrandTotal   string `json:"grand_total"`
+	Fee          string `json:"fee"`
+	Earnings     string `json:"earnings"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+type TransactionDetails struct {
+	Totals TransactionTotals `json:"totals"`
+}
+
+type TransactionPayment struct {
+	PaymentAttemptID      string     `json:"payment_attempt_id"`
+	StoredPaymentMethodID string     `json:"stored_payment_method_id"`
+	Amount                string     `json:"amount"`
+	Status                string     `json:"status"`
+	ErrorCode             *string    `json:"error_code"`
+	CreatedAt             time.Time  `json:"created_at"`
+	CapturedAt            *time.Time `json:"captured_at"`
+}
+
+type TransactionCheckout struct {
+	URL *string `json:"url"`
+}
+
+// Transaction refer to https://developer.paddle.com/api-reference/transactions/overview
+type Transaction struct {
+	ID             string               `json:"id"`
+	Status         string               `json:"status"`
+	CustomerID     *string              `json:"customer_id"`
+	AddressID      *string              `json:"address_id"`
+	BusinessID     *string              `json:"business_id"`
+	CustomData     json.RawMessage      `json:"custom_data"`
+	CurrencyCode   string               `json:"currency_code"`
+	Origin         string               `json:"origin"`
+	SubscriptionID *string              `json:"subscription_id"`
+	InvoiceID      *string              `json:"invoice_id"`
+	InvoiceNumber  *string              `json:"invoice_number"`
+	CollectionMode string               `json:"collection_mode"`
+	DiscountID     *string              `json:"discount_id"`
+	BillingDetails *BillingDetails      `json:"billing_details"`
+	BillingPeriod  *Period              `json:"billing_period"`
+	Items          []TransactionItem    `json:"items"`
+	Details        TransactionDetails   `json:"details"`
+	Payments       []TransactionPayment `json:"payments"`
+	Checkout       *TransactionCheckout `json:"checkout"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+	BilledAt       *time.Time           `json:"billed_at"`
+}
+
+// TransactionNotification is sent for transaction.* event types.
+type TransactionNotification struct {
+	Envelope
+	Data Transaction `json:"data"`
+}
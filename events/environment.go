@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dennor/go-paddle/signature"
+)
+
+// Environment is paddle environment which signed an event.
+type Environment string
+
+const (
+	EnvironmentProduction Environment = "production"
+	EnvironmentSandbox    Environment = "sandbox"
+)
+
+// EnvironmentVerifier is a Verifier which also reports environment
+// of the key which verified event.
+type EnvironmentVerifier interface {
+	Verifier
+	VerifyEnvironment(Event) (Environment, error)
+}
+
+// EnvironmentKeys verifies events with public key of each environment.
+// Keys are tried in order of environment names, so event verified by key
+// shared by several environments is always reported as the first of them.
+type EnvironmentKeys map[Environment]RSAVerifier
+
+func (k EnvironmentKeys) VerifyEnvironment(e Event) (Environment, error) {
+	data, err := e.Serialize()
+	if err != nil {
+		return "", signature.NewVerificationError(err)
+	}
+	sig, err := e.Signature()
+	if err != nil {
+		return "", signature.NewVerificationError(err)
+	}
+	envs := make([]Environment, 0, len(k))
+	for env := range k {
+		envs = append(envs, env)
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i] < envs[j] })
+	for _, env := range envs {
+		if (signature.RSA)(k[env]).Verify(data, sig) == nil {
+			return env, nil
+		}
+	}
+	return "", signature.NewVerificationError(ErrNoMatchingKey)
+}
+
+func (k EnvironmentKeys) Verify(e Event) error {
+	_, err := k.VerifyEnvironment(e)
+	return err
+}
+
+type environmentKey struct{}
+
+// WithEnvironment returns copy of ctx carrying env.
+func WithEnvironment(ctx context.Context, env Environment) context.Context {
+	return context.WithValue(ctx, environmentKey{}, env)
+}
+
+// EnvironmentFromContext returns environment stored in ctx by WithEnvironment.
+func EnvironmentFromContext(ctx context.Context) (Environment, bool) {
+	env, ok := ctx.Value(environmentKey{}).(Environment)
+	return env, ok
+}
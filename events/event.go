@@ -1,6 +1,8 @@
 package events
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/signature"
 )
 
@@ -21,9 +23,24 @@ func (r RSAVerifier) Verify(e Event) error {
 	return nil
 }
 
+// Info gives access to facts common to paddle alerts, so cross-cutting
+// code does not need to switch on event type. Getters return zero values
+// for fields a particular alert does not have.
+type Info interface {
+	GetAlertName() string
+	// GetAlertID returns alert_id and whether alert carries it.
+	GetAlertID() (int, bool)
+	GetEventTime() time.Time
+	GetPassthrough() string
+	// GetEmail returns customer email address.
+	GetEmail() string
+	GetCheckoutID() string
+}
+
 type Event interface {
 	Serialize() ([]byte, error)
 	Signature() ([]byte, error)
+	Info
 }
 
 type Verifier interface {
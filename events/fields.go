@@ -0,0 +1,152 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dennor/go-paddle/events/types"
+)
+
+const signatureField = "p_signature"
+
+// emailFields lists fields holding customer email in different alerts.
+var emailFields = []string{"email", "customer_email_address", "new_customer_email"}
+
+// Fields holds alert fields exactly as they were decoded from request body.
+// It implements Event so it can be verified with the same verifiers as typed
+// events, but serialization follows paddle reference implementation instead of
+// the typed struct, which keeps signatures valid for fields unknown to this module.
+type Fields map[string]string
+
+// ErrRepeatedField is returned by FieldsFromForm for bodies with a repeated
+// key. Typed decoders take the last value of a key, so verifying any single
+// value would let a signed body be extended with forged values.
+var ErrRepeatedField = errors.New("repeated form field")
+
+// FieldsFromForm decodes application/x-www-form-urlencoded body into Fields.
+// Bodies with a repeated key are rejected with ErrRepeatedField.
+func FieldsFromForm(b []byte) (Fields, error) {
+	values, err := url.ParseQuery(string(b))
+	if err != nil {
+		return nil, err
+	}
+	f := make(Fields, len(values))
+	for k, v := range values {
+		if len(v) > 1 {
+			return nil, fmt.Errorf("%w %q", ErrRepeatedField, k)
+		}
+		if len(v) > 0 {
+			f[k] = v[0]
+		}
+	}
+	return f, nil
+}
+
+// FieldsFromJSON decodes flat JSON object into Fields. Scalars are
+// converted to strings the same way php does it when casting to string.
+func FieldsFromJSON(b []byte) (Fields, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	f := make(Fields, len(m))
+	for k, v := range m {
+		switch tv := v.(type) {
+		case string:
+			f[k] = tv
+		case json.Number:
+			f[k] = tv.String()
+		case bool:
+			if tv {
+				f[k] = "1"
+			} else {
+				f[k] = ""
+			}
+		case nil:
+			f[k] = ""
+		default:
+			return nil, errors.New("field " + k + " is not a scalar value")
+		}
+	}
+	return f, nil
+}
+
+func writePhpString(buf *bytes.Buffer, s string) {
+	buf.WriteString("s:")
+	buf.WriteString(strconv.Itoa(len(s)))
+	buf.WriteString(`:"`)
+	buf.WriteString(s)
+	buf.WriteString(`";`)
+}
+
+// Serialize sorts fields by key, skips p_signature and serializes them
+// as php array of strings.
+func (f Fields) Serialize() ([]byte, error) {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		if k == signatureField {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	buf.WriteString("a:")
+	buf.WriteString(strconv.Itoa(len(keys)))
+	buf.WriteString(":{")
+	for _, k := range keys {
+		writePhpString(&buf, k)
+		writePhpString(&buf, f[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (f Fields) Signature() ([]byte, error) {
+	return []byte(f[signatureField]), nil
+}
+
+func (f Fields) GetAlertName() string {
+	return f["alert_name"]
+}
+
+func (f Fields) GetAlertID() (int, bool) {
+	id, err := strconv.Atoi(f["alert_id"])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func (f Fields) GetEventTime() time.Time {
+	t, err := time.Parse(types.DatetimeFormat, f["event_time"])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (f Fields) GetPassthrough() string {
+	return f["passthrough"]
+}
+
+func (f Fields) GetEmail() string {
+	for _, k := range emailFields {
+		if v := f[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (f Fields) GetCheckoutID() string {
+	return f["checkout_id"]
+}
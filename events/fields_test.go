@@ -0,0 +1,96 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/events/types"
+	"github.com/dennor/go-paddle/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	d := test.Sign(map[string]string{
+		"alert_name":     "transfer_paid",
+		"amount":         "1.230",
+		"currency":       "PLN",
+		"event_time":     "2019-04-15 07:37:53",
+		"payout_id":      "2",
+		"status":         "closed",
+		"new_paddle_key": "value unknown to this module",
+	})
+	verifier := RSAVerifier(signature.RSA{
+		PublicKey: &test.Key.PublicKey,
+	})
+
+	t.Run("FieldsFromForm", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		f, err := FieldsFromForm([]byte(d.URL))
+		require.NoError(err)
+		assert.Equal(Fields(d.M), f)
+		_, err = FieldsFromForm([]byte(d.URL + "&amount=9999"))
+		assert.True(errors.Is(err, ErrRepeatedField))
+	})
+
+	t.Run("FieldsFromJSON", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		f, err := FieldsFromJSON([]byte(d.JSON))
+		require.NoError(err)
+		assert.Equal(Fields(d.M), f)
+	})
+
+	t.Run("FieldsFromJSONScalars", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		f, err := FieldsFromJSON([]byte(`{"a":1.50,"b":true,"c":false,"d":null}`))
+		require.NoError(err)
+		assert.Equal(Fields{"a": "1.50", "b": "1", "c": "", "d": ""}, f)
+		_, err = FieldsFromJSON([]byte(`{"a":{"b":"c"}}`))
+		assert.Error(err)
+	})
+
+	t.Run("Serialize", func(t *testing.T) {
+		assert := assert.New(t)
+		b, err := Fields(d.M).Serialize()
+		assert.NoError(err)
+		assert.Equal(d.PHP, string(b))
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.NoError(verifier.Verify(Fields(d.M)))
+		tampered := make(Fields, len(d.M))
+		for k, v := range d.M {
+			tampered[k] = v
+		}
+		tampered["amount"] = "1.23"
+		assert.Error(verifier.Verify(tampered))
+	})
+
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		f := Fields{
+			"alert_id":               "12",
+			"alert_name":             "high_risk_transaction_created",
+			"checkout_id":            "1-c8a82616c183ad6-377f00add1",
+			"customer_email_address": "jan@kowalski.net",
+			"event_time":             "2019-04-15 07:37:53",
+			"passthrough":            "Example String",
+		}
+		assert.Equal("high_risk_transaction_created", f.GetAlertName())
+		alertID, ok := f.GetAlertID()
+		assert.True(ok)
+		assert.Equal(12, alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, "2019-04-15 07:37:53"), f.GetEventTime())
+		assert.Equal("Example String", f.GetPassthrough())
+		assert.Equal("jan@kowalski.net", f.GetEmail())
+		assert.Equal("1-c8a82616c183ad6-377f00add1", f.GetCheckoutID())
+		_, ok = Fields{}.GetAlertID()
+		assert.False(ok)
+		assert.True(Fields{}.GetEventTime().IsZero())
+	})
+}
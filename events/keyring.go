@@ -0,0 +1,226 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dennor/go-paddle/signature"
+)
+
+// ErrNoMatchingKey is returned by KeyRing when signature does not
+// match any of its keys.
+var ErrNoMatchingKey = errors.New("signature does not match any key")
+
+// Key is a named key of KeyRing. Name identifies key in reports,
+// e.g. "production" or "sandbox". Environment, if set, is reported
+// by VerifyEnvironment for events verified by the key.
+type Key struct {
+	Name        string
+	Environment Environment
+	signature.RSA
+}
+
+type ringKey struct {
+	Key
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// KeyRing verifies events with several keys, e.g. old and new one
+// during rotation or sandbox and production. Event is valid if any
+// key verifies it. KeyRing is safe for concurrent use.
+type KeyRing struct {
+	mu   sync.RWMutex
+	keys []ringKey
+}
+
+func NewKeyRing(keys ...Key) *KeyRing {
+	k := &KeyRing{}
+	for _, key := range keys {
+		k.Add(key)
+	}
+	return k
+}
+
+// Add adds key to ring, replacing key with the same name.
+func (k *KeyRing) Add(key Key) {
+	k.add(ringKey{Key: key})
+}
+
+func (k *KeyRing) add(key ringKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i := range k.keys {
+		if k.keys[i].Name == key.Name {
+			k.keys[i] = key
+			return
+		}
+	}
+	k.keys = append(k.keys, key)
+}
+
+// AddFile loads PEM encoded public key from path and adds it to ring
+// with given options. Key is reloaded by Watch when file changes.
+func (k *KeyRing) AddFile(name, path string, opts signature.RSA) error {
+	return k.AddEnvironmentFile(name, "", path, opts)
+}
+
+// AddEnvironmentFile is like AddFile, but tags key with env.
+func (k *KeyRing) AddEnvironmentFile(name string, env Environment, path string, opts signature.RSA) error {
+	key, err := loadRingKey(Key{Name: name, Environment: env, RSA: opts}, path)
+	if err != nil {
+		return err
+	}
+	k.add(key)
+	return nil
+}
+
+func loadRingKey(key Key, path string) (ringKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ringKey{}, err
+	}
+	key.PublicKey, err = signature.LoadPublicKeyFile(path)
+	if err != nil {
+		return ringKey{}, err
+	}
+	return ringKey{
+		Key:     key,
+		path:    path,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}, nil
+}
+
+// Remove removes key name from ring.
+func (k *KeyRing) Remove(name string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i := range k.keys {
+		if k.keys[i].Name == name {
+			k.keys = append(k.keys[:i], k.keys[i+1:]...)
+			return
+		}
+	}
+}
+
+// Keys returns keys in ring in order they were added.
+func (k *KeyRing) Keys() []Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	keys := make([]Key, len(k.keys))
+	for i := range k.keys {
+		keys[i] = k.keys[i].Key
+	}
+	return keys
+}
+
+// Match returns name of the first key which verifies e.
+func (k *KeyRing) Match(e Event) (string, error) {
+	key, err := k.match(e)
+	return key.Name, err
+}
+
+// VerifyEnvironment returns environment of the first key which verifies e.
+func (k *KeyRing) VerifyEnvironment(e Event) (Environment, error) {
+	key, err := k.match(e)
+	return key.Environment, err
+}
+
+func (k *KeyRing) match(e Event) (Key, error) {
+	data, err := e.Serialize()
+	if err != nil {
+		return Key{}, signature.NewVerificationError(err)
+	}
+	sig, err := e.Signature()
+	if err != nil {
+		return Key{}, signature.NewVerificationError(err)
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, key := range k.keys {
+		if key.RSA.Verify(data, sig) == nil {
+			return key.Key, nil
+		}
+	}
+	return Key{}, signature.NewVerificationError(ErrNoMatchingKey)
+}
+
+func (k *KeyRing) Verify(e Event) error {
+	_, err := k.Match(e)
+	return err
+}
+
+// Reload reloads keys added with AddFile whose files changed since they
+// were loaded. Key which fails to load is kept and its error is returned.
+// Keys removed or replaced while Reload runs are not brought back.
+func (k *KeyRing) Reload() error {
+	var firstErr error
+	for _, key := range k.changed() {
+		reloaded, err := loadRingKey(key.Key, key.path)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		k.replace(key, reloaded)
+	}
+	return firstErr
+}
+
+// changed returns keys whose files changed since they were loaded.
+func (k *KeyRing) changed() []ringKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	var changed []ringKey
+	for _, key := range k.keys {
+		if key.path == "" {
+			continue
+		}
+		info, err := os.Stat(key.path)
+		if err == nil && info.ModTime().Equal(key.modTime) && info.Size() == key.size {
+			continue
+		}
+		changed = append(changed, key)
+	}
+	return changed
+}
+
+// replace replaces old with key if ring still holds old, it does
+// nothing if old was removed or replaced in the meantime.
+func (k *KeyRing) replace(old, key ringKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for i := range k.keys {
+		cur := &k.keys[i]
+		if cur.Name != old.Name {
+			continue
+		}
+		if cur.path == old.path && cur.modTime.Equal(old.modTime) && cur.size == old.size {
+			*cur = key
+		}
+		return
+	}
+}
+
+// Watch calls Reload every interval until ctx is done. Errors are passed
+// to onError if it is not nil.
+func (k *KeyRing) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := k.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package events
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signFields(t *testing.T, key *rsa.PrivateKey, f Fields) Fields {
+	data, err := f.Serialize()
+	require.NoError(t, err)
+	hashed := sha1.Sum(data)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, hashed[:])
+	require.NoError(t, err)
+	signed := Fields{signatureField: base64.StdEncoding.EncodeToString(sig)}
+	for k, v := range f {
+		signed[k] = v
+	}
+	return signed
+}
+
+func writePublicKey(t *testing.T, path string, key *rsa.PublicKey) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+}
+
+func TestKeyRing(t *testing.T) {
+	newKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	f := Fields{"alert_name": "transfer_paid", "amount": "1.23"}
+	signedOld := Fields(test.Sign(f).M)
+	signedNew := signFields(t, newKey, f)
+
+	t.Run("Match", func(t *testing.T) {
+		assert := assert.New(t)
+		ring := NewKeyRing(
+			Key{Name: "old", RSA: signature.RSA{PublicKey: &test.Key.PublicKey}},
+			Key{Name: "new", RSA: signature.RSA{PublicKey: &newKey.PublicKey}},
+		)
+		name, err := ring.Match(signedOld)
+		assert.NoError(err)
+		assert.Equal("old", name)
+		name, err = ring.Match(signedNew)
+		assert.NoError(err)
+		assert.Equal("new", name)
+		assert.NoError(ring.Verify(signedNew))
+
+		ring.Remove("old")
+		err = ring.Verify(signedOld)
+		assert.IsType(signature.VerificationError{}, err)
+		assert.Equal(ErrNoMatchingKey.Error(), err.Error())
+		assert.Len(ring.Keys(), 1)
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		assert := assert.New(t)
+		ring := NewKeyRing(
+			Key{Name: "production", Environment: EnvironmentProduction, RSA: signature.RSA{PublicKey: &test.Key.PublicKey}},
+			Key{Name: "sandbox", Environment: EnvironmentSandbox, RSA: signature.RSA{PublicKey: &newKey.PublicKey}},
+		)
+		var _ EnvironmentVerifier = ring
+		env, err := ring.VerifyEnvironment(signedOld)
+		assert.NoError(err)
+		assert.Equal(EnvironmentProduction, env)
+		env, err = ring.VerifyEnvironment(signedNew)
+		assert.NoError(err)
+		assert.Equal(EnvironmentSandbox, env)
+
+		keys := EnvironmentKeys{
+			EnvironmentProduction: RSAVerifier{PublicKey: &test.Key.PublicKey},
+			EnvironmentSandbox:    RSAVerifier{PublicKey: &newKey.PublicKey},
+		}
+		env, err = keys.VerifyEnvironment(signedNew)
+		assert.NoError(err)
+		assert.Equal(EnvironmentSandbox, env)
+		delete(keys, EnvironmentSandbox)
+		assert.Error(keys.Verify(signedNew))
+
+		shared := EnvironmentKeys{
+			EnvironmentSandbox:    RSAVerifier{PublicKey: &test.Key.PublicKey},
+			EnvironmentProduction: RSAVerifier{PublicKey: &test.Key.PublicKey},
+			"staging":             RSAVerifier{PublicKey: &test.Key.PublicKey},
+		}
+		for i := 0; i < 20; i++ {
+			env, err = shared.VerifyEnvironment(signedOld)
+			assert.NoError(err)
+			assert.Equal(EnvironmentProduction, env, "shared key must report the same environment")
+		}
+
+		ctx := WithEnvironment(context.Background(), EnvironmentSandbox)
+		env, ok := EnvironmentFromContext(ctx)
+		assert.True(ok)
+		assert.Equal(EnvironmentSandbox, env)
+		_, ok = EnvironmentFromContext(context.Background())
+		assert.False(ok)
+	})
+
+	t.Run("EmptyRing", func(t *testing.T) {
+		assert.Error(t, NewKeyRing().Verify(signedOld))
+	})
+
+	t.Run("ReloadFile", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir, err := ioutil.TempDir("", "keyring")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "paddle.pem")
+		writePublicKey(t, path, &test.Key.PublicKey)
+		ring := NewKeyRing()
+		require.NoError(ring.AddEnvironmentFile("production", EnvironmentProduction, path, signature.RSA{}))
+		assert.NoError(ring.Verify(signedOld))
+		assert.Error(ring.Verify(signedNew))
+
+		writePublicKey(t, path, &newKey.PublicKey)
+		// make sure change is visible even on filesystems with coarse mtime
+		require.NoError(os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			ring.Watch(ctx, time.Millisecond, nil)
+			close(done)
+		}()
+		for deadline := time.Now().Add(time.Second); ring.Verify(signedNew) != nil && time.Now().Before(deadline); {
+			time.Sleep(time.Millisecond)
+		}
+		env, err := ring.VerifyEnvironment(signedNew)
+		assert.NoError(err)
+		assert.Equal(EnvironmentProduction, env, "reload must keep environment")
+		cancel()
+		<-done
+		assert.Error(ring.Verify(signedOld))
+
+		require.NoError(ioutil.WriteFile(path, []byte("broken"), 0600))
+		require.NoError(os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+		assert.Error(ring.Reload())
+		assert.NoError(ring.Verify(signedNew), "broken file must not remove key")
+	})
+
+	t.Run("RemoveDuringReload", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir, err := ioutil.TempDir("", "keyring")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "paddle.pem")
+		writePublicKey(t, path, &test.Key.PublicKey)
+		ring := NewKeyRing()
+		require.NoError(ring.AddFile("production", path, signature.RSA{}))
+		require.NoError(ring.AddFile("rotated", path, signature.RSA{}))
+		writePublicKey(t, path, &newKey.PublicKey)
+		require.NoError(os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+		// Reload split at the point where it loads changed files unlocked
+		changed := ring.changed()
+		require.Len(changed, 2)
+		ring.Remove("production")
+		ring.Add(Key{Name: "rotated", RSA: signature.RSA{PublicKey: &test.Key.PublicKey}})
+		for _, key := range changed {
+			reloaded, err := loadRingKey(key.Key, key.path)
+			require.NoError(err)
+			ring.replace(key, reloaded)
+		}
+		keys := ring.Keys()
+		if assert.Len(keys, 1, "removed key must not be added back") {
+			assert.Equal("rotated", keys[0].Name)
+		}
+		assert.Error(ring.Verify(signedNew), "replaced key must not be overwritten")
+		assert.NoError(ring.Verify(signedOld))
+	})
+
+	t.Run("AddFileMissing", func(t *testing.T) {
+		err := NewKeyRing().AddFile("production", "/nonexistent/paddle.pem", signature.RSA{})
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
@@ -0,0 +1,43 @@
+package events
+
+import "time"
+
+// Raw is an event with alert name not registered in Registry. It carries
+// fields decoded from request body and can be verified like any other event,
+// signature is checked against Fields.
+type Raw struct {
+	AlertName string
+	Fields    Fields
+}
+
+func (r *Raw) Serialize() ([]byte, error) {
+	return r.Fields.Serialize()
+}
+
+func (r *Raw) Signature() ([]byte, error) {
+	return r.Fields.Signature()
+}
+
+func (r *Raw) GetAlertName() string {
+	return r.AlertName
+}
+
+func (r *Raw) GetAlertID() (int, bool) {
+	return r.Fields.GetAlertID()
+}
+
+func (r *Raw) GetEventTime() time.Time {
+	return r.Fields.GetEventTime()
+}
+
+func (r *Raw) GetPassthrough() string {
+	return r.Fields.GetPassthrough()
+}
+
+func (r *Raw) GetEmail() string {
+	return r.Fields.GetEmail()
+}
+
+func (r *Raw) GetCheckoutID() string {
+	return r.Fields.GetCheckoutID()
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Constructor returns new, empty event ready to be decoded into.
+type Constructor func() Event
+
+// Registry maps alert names to event constructors.
+type Registry struct {
+	mu           sync.RWMutex
+	constructors map[string]Constructor
+	names        map[reflect.Type]string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		constructors: make(map[string]Constructor),
+		names:        make(map[reflect.Type]string),
+	}
+}
+
+// Register adds constructor for alert name. Registering the same
+// name again replaces previous constructor.
+func (r *Registry) Register(name string, c Constructor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.constructors[name] = c
+	r.names[reflect.TypeOf(c())] = name
+}
+
+// New returns new event for alert name.
+func (r *Registry) New(name string) (Event, bool) {
+	r.mu.RLock()
+	c, ok := r.constructors[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return c(), true
+}
+
+// Name returns alert name under which type of e was registered.
+func (r *Registry) Name(e Event) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.names[reflect.TypeOf(e)]
+	return name, ok
+}
+
+// Names returns sorted list of registered alert names.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.constructors))
+	for name := range r.constructors {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Clone returns copy of registry, which can be extended without
+// affecting the original.
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := NewRegistry()
+	for name, ctor := range r.constructors {
+		c.constructors[name] = ctor
+	}
+	for t, name := range r.names {
+		c.names[t] = name
+	}
+	return c
+}
+
+// DefaultRegistry is used when no registry is configured. Packages
+// alerts and subscription register their events in it on import.
+var DefaultRegistry = NewRegistry()
+
+// Register adds constructor for alert name to DefaultRegistry.
+func Register(name string, c Constructor) {
+	DefaultRegistry.Register(name, c)
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type registryEvent struct {
+	AlertName string `json:"alert_name"`
+}
+
+func (r *registryEvent) Serialize() ([]byte, error) { return nil, nil }
+func (r *registryEvent) Signature() ([]byte, error) { return nil, nil }
+func (r *registryEvent) GetAlertName() string       { return r.AlertName }
+func (r *registryEvent) GetAlertID() (int, bool)    { return 0, false }
+func (r *registryEvent) GetEventTime() time.Time    { return time.Time{} }
+func (r *registryEvent) GetPassthrough() string     { return "" }
+func (r *registryEvent) GetEmail() string           { return "" }
+func (r *registryEvent) GetCheckoutID() string      { return "" }
+
+type otherRegistryEvent struct {
+	registryEvent
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		assert := assert.New(t)
+		r := NewRegistry()
+		r.Register("registry_event", func() Event { return new(registryEvent) })
+		e, ok := r.New("registry_event")
+		assert.True(ok)
+		assert.Equal(&registryEvent{}, e)
+		e2, _ := r.New("registry_event")
+		assert.False(e == e2, "constructor must return new event each time")
+		_, ok = r.New("unknown")
+		assert.False(ok)
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		assert := assert.New(t)
+		r := NewRegistry()
+		r.Register("registry_event", func() Event { return new(registryEvent) })
+		name, ok := r.Name(&registryEvent{})
+		assert.True(ok)
+		assert.Equal("registry_event", name)
+		_, ok = r.Name(&otherRegistryEvent{})
+		assert.False(ok)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		assert := assert.New(t)
+		r := NewRegistry()
+		r.Register("registry_event", func() Event { return new(registryEvent) })
+		r.Register("registry_event", func() Event { return new(otherRegistryEvent) })
+		e, ok := r.New("registry_event")
+		assert.True(ok)
+		assert.IsType(&otherRegistryEvent{}, e)
+	})
+
+	t.Run("NamesAndClone", func(t *testing.T) {
+		assert := assert.New(t)
+		r := NewRegistry()
+		r.Register("b", func() Event { return new(registryEvent) })
+		r.Register("a", func() Event { return new(otherRegistryEvent) })
+		c := r.Clone()
+		c.Register("c", func() Event { return new(registryEvent) })
+		assert.Equal([]string{"a", "b"}, r.Names())
+		assert.Equal([]string{"a", "b", "c"}, c.Names())
+	})
+}
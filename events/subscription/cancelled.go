@@ -1,6 +1,8 @@
 package subscription
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -36,3 +38,27 @@ func (s *Cancelled) Serialize() ([]byte, error) {
 func (s *Cancelled) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *Cancelled) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *Cancelled) GetAlertID() (int, bool) {
+	return s.AlertID, true
+}
+
+func (s *Cancelled) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *Cancelled) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *Cancelled) GetEmail() string {
+	return s.Email
+}
+
+func (s *Cancelled) GetCheckoutID() string {
+	return s.CheckoutID
+}
@@ -91,6 +91,18 @@ func TestCancelled(t *testing.T) {
 		}).Verify(&data.sc))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.sc.GetAlertName())
+		alertID, ok := data.sc.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.sc.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.sc.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.sc.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.sc.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &Cancelled{})
@@ -1,6 +1,8 @@
 package subscription
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -40,3 +42,27 @@ func (s *Created) Serialize() ([]byte, error) {
 func (s *Created) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *Created) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *Created) GetAlertID() (int, bool) {
+	return s.AlertID, true
+}
+
+func (s *Created) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *Created) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *Created) GetEmail() string {
+	return s.Email
+}
+
+func (s *Created) GetCheckoutID() string {
+	return s.CheckoutID
+}
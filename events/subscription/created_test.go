@@ -144,6 +144,20 @@ func TestCreated(t *testing.T) {
 		}
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		for _, d := range data {
+			assert.Equal(d.d.M["alert_name"], d.sc.GetAlertName())
+			alertID, ok := d.sc.GetAlertID()
+			assert.True(ok)
+			assert.Equal(int(test.IntFromString(d.d.M["alert_id"])), alertID)
+			assert.Equal(test.ParseTime(types.DatetimeFormat, d.d.M["event_time"]), d.sc.GetEventTime())
+			assert.Equal(d.d.M["passthrough"], d.sc.GetPassthrough())
+			assert.Equal(d.d.M["email"], d.sc.GetEmail())
+			assert.Equal(d.d.M["checkout_id"], d.sc.GetCheckoutID())
+		}
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &Created{})
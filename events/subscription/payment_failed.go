@@ -1,6 +1,8 @@
 package subscription
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -43,3 +45,27 @@ func (s *PaymentFailed) Serialize() ([]byte, error) {
 func (s *PaymentFailed) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *PaymentFailed) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *PaymentFailed) GetAlertID() (int, bool) {
+	return s.AlertID, true
+}
+
+func (s *PaymentFailed) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *PaymentFailed) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *PaymentFailed) GetEmail() string {
+	return s.Email
+}
+
+func (s *PaymentFailed) GetCheckoutID() string {
+	return s.CheckoutID
+}
@@ -95,6 +95,18 @@ func TestPaymentFailed(t *testing.T) {
 		}).Verify(&data.spf))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.spf.GetAlertName())
+		alertID, ok := data.spf.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.spf.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.spf.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.spf.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.spf.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &PaymentFailed{})
@@ -1,6 +1,8 @@
 package subscription
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -49,3 +51,27 @@ func (s *PaymentRefunded) Serialize() ([]byte, error) {
 func (s *PaymentRefunded) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *PaymentRefunded) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *PaymentRefunded) GetAlertID() (int, bool) {
+	return s.AlertID, true
+}
+
+func (s *PaymentRefunded) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *PaymentRefunded) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *PaymentRefunded) GetEmail() string {
+	return s.Email
+}
+
+func (s *PaymentRefunded) GetCheckoutID() string {
+	return s.CheckoutID
+}
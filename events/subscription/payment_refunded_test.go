@@ -119,6 +119,18 @@ func TestPaymentRefunded(t *testing.T) {
 		}).Verify(&data.spr))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.spr.GetAlertName())
+		alertID, ok := data.spr.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.spr.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.spr.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.spr.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.spr.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &PaymentRefunded{})
@@ -1,6 +1,8 @@
 package subscription
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -55,3 +57,27 @@ func (s *PaymentSucceeded) Serialize() ([]byte, error) {
 func (s *PaymentSucceeded) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *PaymentSucceeded) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *PaymentSucceeded) GetAlertID() (int, bool) {
+	return s.AlertID, true
+}
+
+func (s *PaymentSucceeded) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *PaymentSucceeded) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *PaymentSucceeded) GetEmail() string {
+	return s.Email
+}
+
+func (s *PaymentSucceeded) GetCheckoutID() string {
+	return s.CheckoutID
+}
@@ -125,6 +125,18 @@ func TestPaymentSucceeded(t *testing.T) {
 		}).Verify(&data.sps))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.sps.GetAlertName())
+		alertID, ok := data.sps.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.sps.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.sps.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.sps.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.sps.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &PaymentSucceeded{})
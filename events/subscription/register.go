@@ -0,0 +1,17 @@
+package subscription
+
+import "github.com/dennor/go-paddle/events"
+
+func init() {
+	Register(events.DefaultRegistry)
+}
+
+// Register adds all subscription alerts from this package to registry.
+func Register(r *events.Registry) {
+	r.Register(CancelledAlertName, func() events.Event { return new(Cancelled) })
+	r.Register(CreatedAlertName, func() events.Event { return new(Created) })
+	r.Register(PaymentFailedAlertName, func() events.Event { return new(PaymentFailed) })
+	r.Register(PaymentRefundedAlertName, func() events.Event { return new(PaymentRefunded) })
+	r.Register(PaymentSucceededAlertName, func() events.Event { return new(PaymentSucceeded) })
+	r.Register(UpdatedAlertName, func() events.Event { return new(Updated) })
+}
@@ -1,6 +1,8 @@
 package subscription
 
 import (
+	"time"
+
 	"github.com/dennor/go-paddle/events/types"
 	"github.com/dennor/phpserialize"
 )
@@ -45,3 +47,27 @@ func (s *Updated) Serialize() ([]byte, error) {
 func (s *Updated) Signature() ([]byte, error) {
 	return []byte(s.PSignature), nil
 }
+
+func (s *Updated) GetAlertName() string {
+	return s.AlertName
+}
+
+func (s *Updated) GetAlertID() (int, bool) {
+	return s.AlertID, true
+}
+
+func (s *Updated) GetEventTime() time.Time {
+	return s.EventTime.TimeOrZero()
+}
+
+func (s *Updated) GetPassthrough() string {
+	return s.Passthrough
+}
+
+func (s *Updated) GetEmail() string {
+	return s.Email
+}
+
+func (s *Updated) GetCheckoutID() string {
+	return s.CheckoutID
+}
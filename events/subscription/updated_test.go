@@ -105,6 +105,18 @@ func TestUpdated(t *testing.T) {
 		}).Verify(&data.su))
 	})
 
+	t.Run("Info", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal(data.d.M["alert_name"], data.su.GetAlertName())
+		alertID, ok := data.su.GetAlertID()
+		assert.True(ok)
+		assert.Equal(int(test.IntFromString(data.d.M["alert_id"])), alertID)
+		assert.Equal(test.ParseTime(types.DatetimeFormat, data.d.M["event_time"]), data.su.GetEventTime())
+		assert.Equal(data.d.M["passthrough"], data.su.GetPassthrough())
+		assert.Equal(data.d.M["email"], data.su.GetEmail())
+		assert.Equal(data.d.M["checkout_id"], data.su.GetCheckoutID())
+	})
+
 	t.Run("ImplementsEvent", func(t *testing.T) {
 		assert := assert.New(t)
 		assert.Implements((*events.Event)(nil), &Updated{})
@@ -76,6 +76,14 @@ func (t Datetime) GoString() string {
 	return t.String()
 }
 
+// TimeOrZero returns time or zero time if t is nil.
+func (t *Datetime) TimeOrZero() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
+
 type MarketingConsent int8
 
 const abc = 1
@@ -1,6 +1,6 @@
 module github.com/dennor/go-paddle
 
-go 1.12
+go 1.13
 
 require (
 	github.com/dennor/phpserialize v0.0.0-20200608132453-6ba6b4e77720
@@ -1,6 +1,9 @@
 package httperrors
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
 
 type Error interface {
 	error
@@ -20,6 +23,14 @@ func (h httpError) Error() string {
 	return h.message
 }
 
+func (h httpError) Status() int {
+	return h.status
+}
+
+func (h httpError) Detail() string {
+	return h.message
+}
+
 func NewHttpError(m string, status int) Error {
 	return httpError{status, m}
 }
@@ -32,6 +43,28 @@ func NewUnauthorizedError(m string) Error {
 	return NewHttpError(m, http.StatusUnauthorized)
 }
 
+func NewForbiddenError(m string) Error {
+	return NewHttpError(m, http.StatusForbidden)
+}
+
+func NewRequestEntityTooLargeError(m string) Error {
+	return NewHttpError(m, http.StatusRequestEntityTooLarge)
+}
+
 func NewInternalServerError(m string) Error {
 	return NewHttpError(m, http.StatusInternalServerError)
 }
+
+// StatusCode returns HTTP status of the first error in chain of err
+// which has Status method, 500 if there is none.
+func StatusCode(err error) int {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p.Status
+	}
+	var s interface{ Status() int }
+	if errors.As(err, &s) {
+		return s.Status()
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,64 @@
+package httperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/dennor/go-paddle/mime"
+)
+
+// Problem is Error responding with RFC 7807 problem details. Err is
+// the cause of the problem, it is never written to the client.
+type Problem struct {
+	Type      string `json:"type,omitempty"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	AlertName string `json:"alert_name,omitempty"`
+	Err       error  `json:"-"`
+}
+
+// ProblemTypeBlank is problem type of problems described by their status
+// only, callers may set Type of Problem to their own problem type URI.
+const ProblemTypeBlank = "about:blank"
+
+// NewProblem creates problem from err. Type is ProblemTypeBlank, Status is
+// taken from err, see StatusCode, Title is its status text and Detail is
+// taken from err if it has Detail method returning message safe to show
+// to the client.
+func NewProblem(err error) *Problem {
+	status := StatusCode(err)
+	p := &Problem{
+		Type:   ProblemTypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Err:    err,
+	}
+	var d interface{ Detail() string }
+	if errors.As(err, &d) {
+		p.Detail = d.Detail()
+	}
+	return p
+}
+
+func (p *Problem) Error() string {
+	if p.Err != nil {
+		return p.Err.Error()
+	}
+	if p.Detail != "" {
+		return p.Detail
+	}
+	return p.Title
+}
+
+func (p *Problem) Unwrap() error {
+	return p.Err
+}
+
+func (p *Problem) WriteTo(rw http.ResponseWriter) {
+	rw.Header().Set(mime.ContentTypeHeader, mime.ApplicationProblemJSON)
+	rw.Header().Set("X-Content-Type-Options", "nosniff")
+	rw.WriteHeader(p.Status)
+	json.NewEncoder(rw).Encode(p)
+}
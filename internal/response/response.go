@@ -0,0 +1,61 @@
+// Package response records status of HTTP responses written by handlers.
+package response
+
+import "net/http"
+
+// Writer passes response to ResponseWriter and remembers its status.
+// Writer with nil ResponseWriter discards the response, see NewRecorder.
+type Writer struct {
+	http.ResponseWriter
+	header http.Header
+	status int
+}
+
+// NewWriter returns Writer passing response to rw.
+func NewWriter(rw http.ResponseWriter) *Writer {
+	return &Writer{ResponseWriter: rw}
+}
+
+// NewRecorder returns Writer which discards response.
+func NewRecorder() *Writer {
+	return &Writer{}
+}
+
+func (w *Writer) Header() http.Header {
+	if w.ResponseWriter != nil {
+		return w.ResponseWriter.Header()
+	}
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+	return w.header
+}
+
+func (w *Writer) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.ResponseWriter == nil {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *Writer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	if w.ResponseWriter != nil {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// Status returns status written so far, 0 if nothing was written.
+func (w *Writer) Status() int {
+	return w.status
+}
+
+// Failed reports whether response was written with status other than 2xx.
+func (w *Writer) Failed() bool {
+	return w.status != 0 && (w.status < 200 || w.status > 299)
+}
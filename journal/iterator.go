@@ -0,0 +1,148 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+)
+
+type segmentReader struct {
+	r   io.Reader
+	buf []byte
+}
+
+// next returns payload of next entry and number of bytes it takes in segment.
+// io.EOF is returned at the end of segment, ErrCorrupt for torn or damaged entry,
+// along with number of bytes entry claims to take if its header was read.
+func (s *segmentReader) next() ([]byte, int64, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(s.r, header[:]); err != nil {
+		if err == io.EOF {
+			return nil, 0, err
+		}
+		return nil, 0, ErrCorrupt
+	}
+	l := binary.BigEndian.Uint32(header[:])
+	if cap(s.buf) < int(l) {
+		s.buf = make([]byte, l)
+	}
+	s.buf = s.buf[:l]
+	if _, err := io.ReadFull(s.r, s.buf); err != nil {
+		return nil, int64(headerSize + l), ErrCorrupt
+	}
+	if crc32.Checksum(s.buf, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, int64(headerSize + l), ErrCorrupt
+	}
+	return s.buf, int64(headerSize + l), nil
+}
+
+// Iterator reads journal entries in offset order. Entries appended
+// after iterator was created may or may not be returned.
+type Iterator struct {
+	j        *Journal
+	from     int64
+	segments []int64
+	f        *os.File
+	r        *segmentReader
+	entry    Entry
+	err      error
+}
+
+// Iterator returns iterator starting at entry with offset from.
+func (j *Journal) Iterator(from int64) *Iterator {
+	j.mu.Lock()
+	segments := append([]int64(nil), j.segments...)
+	j.mu.Unlock()
+	return newIterator(j, segments, from)
+}
+
+// ReadDir returns iterator over journal in dir starting at entry with
+// offset from. Journal is not opened for writing, so it is safe to read
+// journal which is being appended to by another process.
+func ReadDir(dir string, from int64) (*Iterator, error) {
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	return newIterator(&Journal{dir: dir}, segments, from), nil
+}
+
+func newIterator(j *Journal, segments []int64, from int64) *Iterator {
+	// skip segments which end before from
+	i := sort.Search(len(segments), func(i int) bool { return segments[i] > from })
+	if i > 0 {
+		i--
+	}
+	return &Iterator{j: j, from: from, segments: segments[i:]}
+}
+
+// Next advances iterator to next entry, it returns false when there are
+// no more entries or an error occurred.
+func (it *Iterator) Next() bool {
+	for it.err == nil {
+		if it.r == nil {
+			if len(it.segments) == 0 {
+				return false
+			}
+			f, err := os.Open(it.j.segmentPath(it.segments[0]))
+			if err != nil {
+				it.err = err
+				return false
+			}
+			it.f = f
+			it.r = &segmentReader{r: bufio.NewReader(f)}
+		}
+		payload, _, err := it.r.next()
+		if err != nil {
+			last := len(it.segments) == 1
+			it.closeSegment()
+			it.segments = it.segments[1:]
+			switch {
+			case err == io.EOF:
+			case last:
+				// entry which is being appended
+				return false
+			default:
+				it.err = err
+			}
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(payload, &e); err != nil {
+			it.err = err
+			return false
+		}
+		if e.Offset < it.from {
+			continue
+		}
+		it.entry = e
+		return true
+	}
+	return false
+}
+
+// Entry returns current entry.
+func (it *Iterator) Entry() Entry {
+	return it.entry
+}
+
+// Err returns error which stopped iteration.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) closeSegment() {
+	if it.f != nil {
+		it.f.Close()
+		it.f, it.r = nil, nil
+	}
+}
+
+func (it *Iterator) Close() error {
+	it.closeSegment()
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package journal implements durable append-only log of received alerts.
+//
+// Journal is split into segment files named after offset of their first
+// entry. Each entry is framed with its length and crc32 checksum and is
+// synced to disk before Append returns, a torn entry at the end of the
+// last segment is truncated when journal is opened. Damaged entry followed
+// by other data is never truncated, Open fails with ErrCorrupt instead.
+package journal
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSegmentSize is used if Options.SegmentSize is not positive.
+	DefaultSegmentSize = 64 << 20
+	segmentExt         = ".seg"
+	headerSize         = 8
+)
+
+var (
+	// ErrCorrupt is returned when entry in the middle of journal fails
+	// checksum or is truncated, Open returns it for the last segment
+	// unless the damaged entry is torn tail of the segment.
+	ErrCorrupt = errors.New("journal: corrupt entry")
+	// ErrClosed is returned by Append after journal was closed.
+	ErrClosed = errors.New("journal: closed")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// Entry is a single alert as it was received.
+type Entry struct {
+	Offset      int64       `json:"offset"`
+	ReceivedAt  time.Time   `json:"received_at"`
+	AlertName   string      `json:"alert_name"`
+	ContentType string      `json:"content_type"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
+
+type Options struct {
+	// SegmentSize is size in bytes after which new segment is started.
+	SegmentSize int64
+}
+
+// Journal is safe for concurrent use.
+type Journal struct {
+	dir         string
+	segmentSize int64
+
+	mu       sync.Mutex
+	segments []int64
+	f        *os.File
+	size     int64
+	next     int64
+	closed   bool
+}
+
+// Open opens journal in dir, creating dir if it does not exist.
+func Open(dir string, opts Options) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	j := &Journal{dir: dir, segmentSize: opts.SegmentSize}
+	if j.segmentSize <= 0 {
+		j.segmentSize = DefaultSegmentSize
+	}
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		if err := j.createSegment(0); err != nil {
+			return nil, err
+		}
+		return j, nil
+	}
+	j.segments = segments
+	first := segments[len(segments)-1]
+	f, err := os.OpenFile(j.segmentPath(first), os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	n, size, err := recoverSegment(f)
+	if err != nil {
+		f.Close()
+		if errors.Is(err, ErrCorrupt) {
+			return nil, fmt.Errorf("%w at offset %d", err, first+n)
+		}
+		return nil, err
+	}
+	j.f, j.size, j.next = f, size, first+n
+	return j, nil
+}
+
+func listSegments(dir string) ([]int64, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []int64
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		first, err := strconv.ParseInt(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, first)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// recoverSegment counts valid entries in f, truncates torn entry after
+// them and leaves f positioned at its end. Entry is torn if it reaches
+// the end of f, damaged entry followed by more data is ErrCorrupt.
+func recoverSegment(f *os.File) (int64, int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	var n, size int64
+	r := &segmentReader{r: f}
+	for {
+		_, l, err := r.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if l > 0 && size+l < info.Size() {
+				return n, size, err
+			}
+			break
+		}
+		n++
+		size += l
+	}
+	if size < info.Size() {
+		if err := f.Truncate(size); err != nil {
+			return 0, 0, err
+		}
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	return n, size, f.Sync()
+}
+
+func (j *Journal) segmentPath(first int64) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%020d%s", first, segmentExt))
+}
+
+// createSegment starts new segment with first entry at offset first, j.mu must be held.
+func (j *Journal) createSegment(first int64) error {
+	f, err := os.OpenFile(j.segmentPath(first), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := syncDir(j.dir); err != nil {
+		f.Close()
+		return err
+	}
+	if j.f != nil {
+		j.f.Close()
+	}
+	j.segments = append(j.segments, first)
+	j.f, j.size, j.next = f, 0, first
+	return nil
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// Append writes e to journal and syncs it to disk. It sets e.Offset and
+// returns it.
+func (j *Journal) Append(e *Entry) (int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return 0, ErrClosed
+	}
+	e.Offset = j.next
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return 0, err
+	}
+	rec := make([]byte, headerSize+len(payload))
+	binary.BigEndian.PutUint32(rec, uint32(len(payload)))
+	binary.BigEndian.PutUint32(rec[4:], crc32.Checksum(payload, crcTable))
+	copy(rec[headerSize:], payload)
+	if j.size > 0 && j.size+int64(len(rec)) > j.segmentSize {
+		if err := j.f.Sync(); err != nil {
+			return 0, err
+		}
+		if err := j.createSegment(j.next); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := j.f.Write(rec); err != nil {
+		return 0, err
+	}
+	if err := j.f.Sync(); err != nil {
+		return 0, err
+	}
+	j.size += int64(len(rec))
+	j.next++
+	return e.Offset, nil
+}
+
+// NextOffset returns offset which will be assigned to next appended entry.
+func (j *Journal) NextOffset() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.next
+}
+
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		return nil
+	}
+	j.closed = true
+	return j.f.Close()
+}
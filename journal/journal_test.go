@@ -0,0 +1,246 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(t, err)
+	return dir
+}
+
+func newEntry(i int) *Entry {
+	return &Entry{
+		ReceivedAt:  time.Date(2019, 4, 15, 7, 37, i, 0, time.UTC),
+		AlertName:   "subscription_created",
+		ContentType: mime.ApplicationForm,
+		Header:      http.Header{"Content-Type": []string{mime.ApplicationForm}},
+		Body:        []byte("alert_name=subscription_created&alert_id=" + strconv.Itoa(i)),
+	}
+}
+
+func collect(t *testing.T, j *Journal, from int64) []Entry {
+	it := j.Iterator(from)
+	defer it.Close()
+	var entries []Entry
+	for it.Next() {
+		entries = append(entries, it.Entry())
+	}
+	require.NoError(t, it.Err())
+	return entries
+}
+
+func TestJournal(t *testing.T) {
+	t.Run("AppendAndIterate", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		j, err := Open(dir, Options{SegmentSize: 256})
+		require.NoError(err)
+		for i := 0; i < 10; i++ {
+			offset, err := j.Append(newEntry(i))
+			require.NoError(err)
+			assert.Equal(int64(i), offset)
+		}
+		segments, err := listSegments(dir)
+		require.NoError(err)
+		assert.True(len(segments) > 1, "journal must be split into segments")
+		entries := collect(t, j, 0)
+		require.Len(entries, 10)
+		for i, e := range entries {
+			expected := newEntry(i)
+			expected.Offset = int64(i)
+			assert.Equal(*expected, e)
+		}
+		entries = collect(t, j, 7)
+		require.Len(entries, 3)
+		assert.Equal(int64(7), entries[0].Offset)
+		assert.Empty(collect(t, j, 10))
+		require.NoError(j.Close())
+		_, err = j.Append(newEntry(10))
+		assert.Equal(ErrClosed, err)
+	})
+
+	t.Run("Reopen", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		j, err := Open(dir, Options{SegmentSize: 256})
+		require.NoError(err)
+		for i := 0; i < 5; i++ {
+			_, err := j.Append(newEntry(i))
+			require.NoError(err)
+		}
+		require.NoError(j.Close())
+		j, err = Open(dir, Options{SegmentSize: 256})
+		require.NoError(err)
+		defer j.Close()
+		assert.Equal(int64(5), j.NextOffset())
+		offset, err := j.Append(newEntry(5))
+		require.NoError(err)
+		assert.Equal(int64(5), offset)
+		assert.Len(collect(t, j, 0), 6)
+	})
+
+	t.Run("TruncatesTornEntry", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		j, err := Open(dir, Options{})
+		require.NoError(err)
+		for i := 0; i < 3; i++ {
+			_, err := j.Append(newEntry(i))
+			require.NoError(err)
+		}
+		require.NoError(j.Close())
+		path := filepath.Join(dir, "00000000000000000000.seg")
+		info, err := os.Stat(path)
+		require.NoError(err)
+		require.NoError(os.Truncate(path, info.Size()-3))
+		j, err = Open(dir, Options{})
+		require.NoError(err)
+		defer j.Close()
+		assert.Equal(int64(2), j.NextOffset())
+		assert.Len(collect(t, j, 0), 2)
+	})
+
+	t.Run("CorruptEntry", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		j, err := Open(dir, Options{SegmentSize: 256})
+		require.NoError(err)
+		defer j.Close()
+		for i := 0; i < 10; i++ {
+			_, err := j.Append(newEntry(i))
+			require.NoError(err)
+		}
+		path := filepath.Join(dir, "00000000000000000000.seg")
+		b, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		b[headerSize+1] ^= 0xff
+		require.NoError(ioutil.WriteFile(path, b, 0600))
+		it := j.Iterator(0)
+		defer it.Close()
+		assert.False(it.Next())
+		assert.Equal(ErrCorrupt, it.Err())
+	})
+	t.Run("CorruptEntryOnOpen", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		j, err := Open(dir, Options{})
+		require.NoError(err)
+		var sizes []int64
+		path := filepath.Join(dir, "00000000000000000000.seg")
+		for i := 0; i < 3; i++ {
+			_, err := j.Append(newEntry(i))
+			require.NoError(err)
+			info, err := os.Stat(path)
+			require.NoError(err)
+			sizes = append(sizes, info.Size())
+		}
+		require.NoError(j.Close())
+		b, err := ioutil.ReadFile(path)
+		require.NoError(err)
+
+		middle := append([]byte(nil), b...)
+		middle[sizes[0]+headerSize+1] ^= 0xff
+		require.NoError(ioutil.WriteFile(path, middle, 0600))
+		_, err = Open(dir, Options{})
+		assert.True(errors.Is(err, ErrCorrupt), "%v", err)
+		after, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		assert.Equal(middle, after, "entries after damaged one must not be truncated")
+
+		last := append([]byte(nil), b...)
+		last[sizes[1]+headerSize+1] ^= 0xff
+		require.NoError(ioutil.WriteFile(path, last, 0600))
+		j, err = Open(dir, Options{})
+		require.NoError(err)
+		defer j.Close()
+		assert.Equal(int64(2), j.NextOffset(), "damaged tail must be truncated")
+	})
+}
+
+func TestReplay(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	j, err := Open(dir, Options{})
+	require.NoError(err)
+	defer j.Close()
+	for i := 0; i < 4; i++ {
+		_, err := j.Append(newEntry(i))
+		require.NoError(err)
+	}
+	var got []string
+	h := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		offset, ok := ReplayOffset(req.Context())
+		assert.True(ok)
+		assert.Equal(mime.ApplicationForm, req.Header.Get(mime.ContentTypeHeader))
+		b, _ := ioutil.ReadAll(req.Body)
+		got = append(got, string(b))
+		if offset == 2 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	next, err := j.Replay(context.Background(), 1, h)
+	assert.Equal(&ReplayError{Offset: 2, Status: http.StatusServiceUnavailable}, err)
+	assert.Equal(int64(2), next)
+	assert.Equal([]string{
+		"alert_name=subscription_created&alert_id=1",
+		"alert_name=subscription_created&alert_id=2",
+	}, got)
+	got = nil
+	next, err = j.Replay(context.Background(), 3, h)
+	assert.NoError(err)
+	assert.Equal(int64(4), next)
+	assert.Len(got, 1)
+	_, ok := ReplayOffset(context.Background())
+	assert.False(ok)
+}
+
+func TestReadDir(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	j, err := Open(dir, Options{SegmentSize: 256})
+	require.NoError(err)
+	defer j.Close()
+	for i := 0; i < 5; i++ {
+		_, err := j.Append(newEntry(i))
+		require.NoError(err)
+	}
+	it, err := ReadDir(dir, 3)
+	require.NoError(err)
+	defer it.Close()
+	var offsets []int64
+	for it.Next() {
+		offsets = append(offsets, it.Entry().Offset)
+	}
+	assert.NoError(it.Err())
+	assert.Equal([]int64{3, 4}, offsets)
+	_, err = ReadDir(filepath.Join(dir, "missing"), 0)
+	assert.Error(err)
+}
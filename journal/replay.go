@@ -0,0 +1,92 @@
+package journal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dennor/go-paddle/internal/response"
+	"github.com/dennor/go-paddle/mime"
+)
+
+type replayKey struct{}
+
+// WithReplay marks ctx as context of replayed entry with offset.
+func WithReplay(ctx context.Context, offset int64) context.Context {
+	return context.WithValue(ctx, replayKey{}, offset)
+}
+
+// ReplayOffset returns offset of replayed entry if ctx belongs to
+// request created by Replay.
+func ReplayOffset(ctx context.Context) (int64, bool) {
+	offset, ok := ctx.Value(replayKey{}).(int64)
+	return offset, ok
+}
+
+// Request recreates request in which e was received. Its context is
+// marked with WithReplay.
+func (e Entry) Request(ctx context.Context) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(WithReplay(ctx, e.Offset), http.MethodPost, "/", bytes.NewReader(e.Body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = e.Header.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	if req.Header.Get(mime.ContentTypeHeader) == "" {
+		req.Header.Set(mime.ContentTypeHeader, e.ContentType)
+	}
+	return req, nil
+}
+
+// ReplayError is returned by Replay when handler did not respond with
+// 2xx status.
+type ReplayError struct {
+	Offset int64
+	Status int
+}
+
+func (r *ReplayError) Error() string {
+	return fmt.Sprintf("journal: replay of entry %d failed with status %d", r.Offset, r.Status)
+}
+
+// Serve passes e to h and returns response status.
+func (e Entry) Serve(ctx context.Context, h http.Handler) (int, error) {
+	req, err := e.Request(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rec := response.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Status() == 0 {
+		return http.StatusOK, nil
+	}
+	return rec.Status(), nil
+}
+
+// Replay feeds entries starting at offset from to h, usually
+// router.Router handler, one by one. It stops at first entry which was not
+// answered with 2xx status and returns *ReplayError. Returned offset is
+// the offset to resume replay from.
+func (j *Journal) Replay(ctx context.Context, from int64, h http.Handler) (int64, error) {
+	it := j.Iterator(from)
+	defer it.Close()
+	next := from
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return next, err
+		}
+		e := it.Entry()
+		status, err := e.Serve(ctx, h)
+		if err != nil {
+			return e.Offset, err
+		}
+		if status < 200 || status > 299 {
+			return e.Offset, &ReplayError{Offset: e.Offset, Status: status}
+		}
+		next = e.Offset + 1
+	}
+	return next, it.Err()
+}
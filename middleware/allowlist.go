@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/dennor/go-paddle/httperrors"
+)
+
+var (
+	// PaddleProductionIPs are addresses paddle sends production alerts from.
+	PaddleProductionIPs = []string{
+		"34.232.58.13",
+		"34.195.105.136",
+		"34.237.3.244",
+		"35.155.119.135",
+		"52.11.166.252",
+		"34.212.5.7",
+	}
+	// PaddleSandboxIPs are addresses paddle sends sandbox alerts from.
+	PaddleSandboxIPs = []string{
+		"34.194.127.46",
+		"54.234.237.108",
+		"3.208.120.145",
+		"44.226.236.210",
+		"44.241.183.62",
+		"100.20.172.113",
+	}
+)
+
+// IPAllowlist rejects requests which do not come from allowed addresses.
+//
+// Client address is taken from request's RemoteAddr. If it belongs to
+// trusted proxies, client address is the last address in Forwarded header,
+// or X-Forwarded-For if there is no Forwarded header, which does not
+// belong to trusted proxies.
+type IPAllowlist struct {
+	allowed []*net.IPNet
+	proxies []*net.IPNet
+}
+
+// NewIPAllowlist creates allowlist of addresses or CIDRs in allowed,
+// e.g. PaddleProductionIPs, trusting forwarding headers set by proxies
+// with addresses or CIDRs in trustedProxies.
+func NewIPAllowlist(allowed, trustedProxies []string) (*IPAllowlist, error) {
+	a := &IPAllowlist{}
+	var err error
+	if a.allowed, err = parseNets(allowed); err != nil {
+		return nil, err
+	}
+	if a.proxies, err = parseNets(trustedProxies); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func parseNets(addrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(addrs))
+	for _, addr := range addrs {
+		if strings.Contains(addr, "/") {
+			_, n, err := net.ParseCIDR(addr)
+			if err != nil {
+				return nil, err
+			}
+			nets = append(nets, n)
+			continue
+		}
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address %q", addr)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets, nil
+}
+
+func contains(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns address of client which sent req,
+// nil if it can not be determined.
+func (a *IPAllowlist) ClientIP(req *http.Request) net.IP {
+	ip := parseHostIP(req.RemoteAddr)
+	if ip == nil || !contains(a.proxies, ip) {
+		return ip
+	}
+	var chain []string
+	if h := req.Header["Forwarded"]; len(h) > 0 {
+		chain = forwardedFor(h)
+	} else {
+		for _, h := range req.Header["X-Forwarded-For"] {
+			for _, addr := range strings.Split(h, ",") {
+				chain = append(chain, strings.TrimSpace(addr))
+			}
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip = parseHostIP(chain[i])
+		if ip == nil || !contains(a.proxies, ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+// Allow returns forbidden error if req does not come from allowed address.
+func (a *IPAllowlist) Allow(req *http.Request) error {
+	ip := a.ClientIP(req)
+	if ip == nil || !contains(a.allowed, ip) {
+		return httperrors.NewForbiddenError("address is not allowed to send alerts")
+	}
+	return nil
+}
+
+// forwardedFor returns for parameters of Forwarded header values.
+func forwardedFor(values []string) []string {
+	var chain []string
+	for _, v := range values {
+		for _, element := range strings.Split(v, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+				if len(kv) == 2 && strings.EqualFold(kv[0], "for") {
+					chain = append(chain, strings.Trim(kv[1], `"`))
+				}
+			}
+		}
+	}
+	return chain
+}
+
+// parseHostIP parses address optionally followed by port,
+// IPv6 addresses with port are enclosed in brackets.
+func parseHostIP(addr string) net.IP {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return net.ParseIP(strings.Trim(addr, "[]"))
+}
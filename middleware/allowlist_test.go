@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAllowlist(t *testing.T) {
+	a, err := NewIPAllowlist(append(PaddleSandboxIPs, "2001:db8::/32"), []string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+	cases := []struct {
+		name    string
+		remote  string
+		header  http.Header
+		client  string
+		allowed bool
+	}{
+		{"Direct", "34.194.127.46:1234", nil, "34.194.127.46", true},
+		{"DirectIPv6", "[2001:db8::1]:1234", nil, "2001:db8::1", true},
+		{"NotAllowed", "34.232.58.13:1234", nil, "34.232.58.13", false},
+		{"UntrustedProxy", "1.2.3.4:1234", http.Header{"X-Forwarded-For": {"34.194.127.46"}}, "1.2.3.4", false},
+		{"XForwardedFor", "10.1.2.3:1234", http.Header{"X-Forwarded-For": {"1.1.1.1, 34.194.127.46, 192.168.1.1"}}, "34.194.127.46", true},
+		{"XForwardedForSpoofed", "10.1.2.3:1234", http.Header{"X-Forwarded-For": {"34.194.127.46, 1.1.1.1"}}, "1.1.1.1", false},
+		{"Forwarded", "10.1.2.3:1234", http.Header{"Forwarded": {`for=1.1.1.1, for="[2001:db8::2]:4711";proto=https`}}, "2001:db8::2", true},
+		{"ForwardedPreferred", "10.1.2.3:1234", http.Header{"Forwarded": {"for=1.1.1.1"}, "X-Forwarded-For": {"34.194.127.46"}}, "1.1.1.1", false},
+		{"ForwardedObfuscated", "10.1.2.3:1234", http.Header{"Forwarded": {"for=_hidden"}}, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tc.remote
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+			if tc.client == "" {
+				assert.Nil(a.ClientIP(req))
+			} else {
+				assert.True(net.ParseIP(tc.client).Equal(a.ClientIP(req)), a.ClientIP(req).String())
+			}
+			if tc.allowed {
+				assert.NoError(a.Allow(req))
+			} else {
+				assert.Error(a.Allow(req))
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewIPAllowlist([]string{"not an ip"}, nil)
+		assert.Error(t, err)
+		_, err = NewIPAllowlist(nil, []string{"10.0.0.0/33"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Handle", func(t *testing.T) {
+		called := false
+		next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) { called = true })
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "1.2.3.4:1234"
+		(&Event{EventConfig: EventConfig{IPAllowlist: a}}).Handle(next).ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+		assert.False(t, called)
+	})
+}
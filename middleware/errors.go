@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dennor/go-paddle/httperrors"
+)
+
+// Classes of errors returned by Event, use errors.Is to test for them.
+var (
+	// ErrUnsupportedMediaType is reported for requests which are neither
+	// form nor JSON encoded.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrUnknownAlert is reported for alerts not found in registry.
+	ErrUnknownAlert = errors.New("unknown alert")
+	// ErrDecode is reported for malformed alerts.
+	ErrDecode = errors.New("malformed alert")
+	// ErrSignature is reported for alerts rejected by Verifier.
+	ErrSignature = errors.New("invalid signature")
+	// ErrBodyRead is reported when request body could not be read.
+	ErrBodyRead = errors.New("could not read request body")
+	// ErrBodyTooLarge is reported for bodies exceeding MaxBodyBytes.
+	ErrBodyTooLarge = errors.New("request body too large")
+	// ErrJournal is reported when alert could not be appended to Journal.
+	ErrJournal = errors.New("could not persist alert")
+)
+
+var classStatus = map[error]int{
+	ErrUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	ErrUnknownAlert:         http.StatusBadRequest,
+	ErrDecode:               http.StatusBadRequest,
+	ErrSignature:            http.StatusForbidden,
+	ErrBodyRead:             http.StatusBadRequest,
+	ErrBodyTooLarge:         http.StatusRequestEntityTooLarge,
+	ErrJournal:              http.StatusInternalServerError,
+}
+
+// Error is failure of class Class, one of Err variables, caused by Err.
+// It responds with status of its class and message of the class only,
+// so details of Err are not leaked to the client. AlertName is name
+// of the alert if it is known.
+type Error struct {
+	Class     error
+	Err       error
+	AlertName string
+}
+
+func newError(class, err error) Error {
+	return Error{Class: class, Err: err}
+}
+
+func (e Error) Error() string {
+	if e.Err == nil {
+		return e.Class.Error()
+	}
+	return e.Class.Error() + ": " + e.Err.Error()
+}
+
+func (e Error) Is(target error) bool {
+	return target == e.Class
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Status returns HTTP status of error class.
+func (e Error) Status() int {
+	if status, ok := classStatus[e.Class]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Detail returns message of error class.
+func (e Error) Detail() string {
+	return e.Class.Error()
+}
+
+func (e Error) WriteTo(rw http.ResponseWriter) {
+	http.Error(rw, e.Detail(), e.Status())
+}
+
+// ErrorHandler writes response for err which occurred while serving req.
+// Err may carry internal details which should not be written to the client.
+type ErrorHandler func(rw http.ResponseWriter, req *http.Request, err error)
+
+// WriteProblem is ErrorHandler responding with httperrors.Problem, alert
+// name is filled for errors returned by Event.
+func WriteProblem(rw http.ResponseWriter, req *http.Request, err error) {
+	p := httperrors.NewProblem(err)
+	var eventErr Error
+	var replayErr ReplayError
+	switch {
+	case errors.As(err, &eventErr):
+		p.AlertName = eventErr.AlertName
+	case errors.As(err, &replayErr):
+		p.AlertName = replayErr.AlertName
+	}
+	p.WriteTo(rw)
+}
@@ -3,16 +3,22 @@ package middleware
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/dennor/go-paddle/events"
-	"github.com/dennor/go-paddle/events/alerts"
-	"github.com/dennor/go-paddle/events/subscription"
+	// register built-in events in events.DefaultRegistry
+	_ "github.com/dennor/go-paddle/events/alerts"
+	_ "github.com/dennor/go-paddle/events/subscription"
 	"github.com/dennor/go-paddle/httperrors"
+	"github.com/dennor/go-paddle/internal/response"
+	"github.com/dennor/go-paddle/journal"
 	"github.com/dennor/go-paddle/mime"
 )
 
@@ -34,6 +40,40 @@ type EventConfig struct {
 	CopyBody        bool
 	ContinueOnError bool
 	SkipContext     bool
+	// Registry is used to create events by alert name,
+	// events.DefaultRegistry is used if nil.
+	Registry *events.Registry
+	// VerifyFields makes Verifier check signature against raw fields
+	// decoded from request body instead of the typed event, so fields
+	// added by paddle and unknown to this module do not break verification.
+	VerifyFields bool
+	// UnknownAsRaw makes alerts with names not found in Registry
+	// decode into *events.Raw instead of failing with bad request.
+	UnknownAsRaw bool
+	// Journal persists every verified alert before it is passed on,
+	// alert which could not be appended fails with internal server error.
+	// Requests replayed from journal are not appended again.
+	Journal Journal
+	// Freshness, if set, rejects verified alerts which are too old, were
+	// already accepted or are in flight with ReplayError. Requests replayed
+	// from journal are not checked. Handle accepts alerts answered with
+	// 2xx and forgets the others, see Freshness.
+	Freshness *Freshness
+	// MaxBodyBytes limits size of request body, larger bodies are rejected
+	// with request entity too large. Zero means no limit.
+	MaxBodyBytes int64
+	// IPAllowlist, if set, rejects requests from other addresses with
+	// forbidden before their body is read. Requests replayed from
+	// journal are not checked, they were allowed when first received.
+	IPAllowlist *IPAllowlist
+	// ErrorHandler, if set, writes responses for errors unless
+	// ContinueOnError is set, errors write themselves otherwise.
+	ErrorHandler ErrorHandler
+}
+
+// Journal is implemented by *journal.Journal.
+type Journal interface {
+	Append(*journal.Entry) (int64, error)
 }
 
 type Event struct {
@@ -61,9 +101,17 @@ func (p *bodyBufferPool) Get() *buffer {
 }
 
 func (p *bodyBufferPool) Put(buf *buffer) {
+	// let oversized buffers be collected instead of pinning their memory
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
 	p.Pool.Put(buf)
 }
 
+// maxPooledBufferSize is capacity above which body buffers are
+// not reused, paddle alerts are a few kilobytes.
+const maxPooledBufferSize = 64 << 10
+
 var (
 	bodyPool = newBodyBufferPool()
 )
@@ -86,7 +134,7 @@ func eventNameFromURLEncoded(b []byte) string {
 			i++
 		}
 		for _, ac := range alertKey {
-			if b[i] != ac || i >= len(b) {
+			if i >= len(b) || b[i] != ac {
 				break
 			}
 			j++
@@ -106,92 +154,155 @@ func eventNameFromURLEncoded(b []byte) string {
 	return ""
 }
 
+type jsonAlertName struct {
+	AlertName string `json:"alert_name"`
+}
+
+func eventNameFromJSON(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	var n jsonAlertName
+	if err := json.Unmarshal(b, &n); err != nil {
+		return ""
+	}
+	return n.AlertName
+}
+
+// AlertName returns name of alert in body b encoded as contentType, found
+// the same way Event middleware finds it. Empty string is returned if body
+// has no alert name or contentType is not supported.
+func AlertName(contentType string, b []byte) string {
+	switch {
+	case strings.HasPrefix(contentType, mime.ApplicationForm):
+		// copy, eventNameFromURLEncoded shares memory with b
+		return string([]byte(eventNameFromURLEncoded(b)))
+	case strings.HasPrefix(contentType, mime.ApplicationJSON):
+		return eventNameFromJSON(b)
+	}
+	return ""
+}
+
 type unmarshalFunc func(io.Reader, interface{}) error
 
+type fieldsFunc func([]byte) (events.Fields, error)
+
 var (
 	unmarshalForm = events.UnmarshalForm
 	unmarshalJSON = events.UnmarshalJSON
+	formFields    = events.FieldsFromForm
+	jsonFields    = events.FieldsFromJSON
 )
 
-func unmarshalEvent(ename string, r io.Reader, f unmarshalFunc) (events.Event, error) {
-	var e events.Event
-	switch ename {
-	case subscription.CancelledAlertName:
-		e = new(subscription.Cancelled)
-	case subscription.CreatedAlertName:
-		e = new(subscription.Created)
-	case subscription.PaymentFailedAlertName:
-		e = new(subscription.PaymentFailed)
-	case subscription.PaymentRefundedAlertName:
-		e = new(subscription.PaymentRefunded)
-	case subscription.PaymentSucceededAlertName:
-		e = new(subscription.PaymentSucceeded)
-	case subscription.UpdatedAlertName:
-		e = new(subscription.Updated)
-	case alerts.HighRiskTransactionCreatedAlertName:
-		e = new(alerts.HighRiskTransactionCreated)
-	case alerts.HighRiskTransactionUpdatedAlertName:
-		e = new(alerts.HighRiskTransactionUpdated)
-	case alerts.LockerProcessedAlertName:
-		e = new(alerts.LockerProcessed)
-	case alerts.NewAudienceMemberAlertName:
-		e = new(alerts.NewAudienceMember)
-	case alerts.PaymentDisputeClosedAlertName:
-		e = new(alerts.PaymentDisputeClosed)
-	case alerts.PaymentDisputeCreatedAlertName:
-		e = new(alerts.PaymentDisputeCreated)
-	case alerts.PaymentRefundedAlertName:
-		e = new(alerts.PaymentRefunded)
-	case alerts.PaymentSucceededAlertName:
-		e = new(alerts.PaymentSucceeded)
-	case alerts.TransferCreatedAlertName:
-		e = new(alerts.TransferCreated)
-	case alerts.TransferPaidAlertName:
-		e = new(alerts.TransferPaid)
-	case alerts.UpdateAudienceMemberAlertName:
-		e = new(alerts.UpdateAudienceMember)
-	default:
-		return nil, httperrors.NewBadRequestError(ename + " is not a supported event type")
+type readOptions struct {
+	registry     *events.Registry
+	copyBody     bool
+	withFields   bool
+	unknownAsRaw bool
+	maxBodyBytes int64
+}
+
+// contextReader fails reads once ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
 	}
-	return e, f(r, e)
+	return c.r.Read(p)
 }
 
-func readEventFromRequest(req *http.Request, copyBody bool) (events.Event, error) {
+// readBody reads body of req into buf. It stops when request context
+// is done or, if max is positive, when body exceeds max bytes.
+func readBody(buf *buffer, req *http.Request, max int64) error {
+	var r io.Reader = contextReader{ctx: req.Context(), r: req.Body}
+	if max > 0 {
+		r = io.LimitReader(r, max+1)
+	}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return newError(ErrBodyRead, err)
+	}
+	if max > 0 && int64(buf.Len()) > max {
+		return newError(ErrBodyTooLarge, nil)
+	}
+	return nil
+}
+
+func readEventFromRequest(req *http.Request, opts readOptions) (events.Event, events.Fields, error) {
 	buf := bodyPool.Get()
-	if _, err := io.Copy(buf, req.Body); err != nil {
-		return nil, err
+	if err := readBody(buf, req, opts.maxBodyBytes); err != nil {
+		bodyPool.Put(buf)
+		return nil, nil, err
 	}
 	var ename string
 	var f unmarshalFunc
+	var ff fieldsFunc
 	switch {
 	case strings.HasPrefix(req.Header.Get(mime.ContentTypeHeader), mime.ApplicationForm):
 		ename = eventNameFromURLEncoded(buf.Bytes())
 		f = unmarshalForm
+		ff = formFields
+	case strings.HasPrefix(req.Header.Get(mime.ContentTypeHeader), mime.ApplicationJSON):
+		ename = eventNameFromJSON(buf.Bytes())
+		f = unmarshalJSON
+		ff = jsonFields
 	default:
-		return nil, httperrors.NewBadRequestError(req.Header.Get(mime.ContentTypeHeader) + " is not supported mime type")
+		bodyPool.Put(buf)
+		return nil, nil, newError(ErrUnsupportedMediaType, errors.New(req.Header.Get(mime.ContentTypeHeader)+" is not supported mime type"))
+	}
+	e, registered := opts.registry.New(ename)
+	var fields events.Fields
+	if opts.withFields || (!registered && opts.unknownAsRaw) {
+		var err error
+		if fields, err = ff(buf.Bytes()); err != nil {
+			err = Error{Class: ErrDecode, Err: err, AlertName: AlertName(req.Header.Get(mime.ContentTypeHeader), buf.Bytes())}
+			bodyPool.Put(buf)
+			return nil, nil, err
+		}
 	}
 	var r io.Reader
 	r = buf
-	if copyBody {
+	if opts.copyBody {
 		r = bytes.NewReader(buf.Bytes())
 	}
-	e, err := unmarshalEvent(ename, r, f)
-	if copyBody {
+	var err error
+	switch {
+	case registered:
+		if err = f(r, e); err != nil {
+			err = Error{Class: ErrDecode, Err: err, AlertName: AlertName(req.Header.Get(mime.ContentTypeHeader), buf.Bytes())}
+		}
+	case opts.unknownAsRaw:
+		// ename points into pooled buffer, take the name from decoded fields instead
+		e = &events.Raw{AlertName: fields["alert_name"], Fields: fields}
+	default:
+		// ename points into pooled buffer, copy it
+		name := string([]byte(ename))
+		err = Error{Class: ErrUnknownAlert, Err: errors.New(name + " is not a supported event type"), AlertName: name}
+	}
+	if opts.copyBody {
 		req.Body.Close()
 		req.Body = buf
 	} else {
 		bodyPool.Put(buf)
 	}
-	return e, err
+	return e, fields, err
 }
 
 func (e *Event) onError(next http.Handler, rw http.ResponseWriter, req *http.Request, err error) {
 	if !e.ContinueOnError {
+		// errors with success status, e.g. replays, acknowledge the alert
+		if e.ErrorHandler != nil && httperrors.StatusCode(err) >= http.StatusBadRequest {
+			e.ErrorHandler(rw, req, err)
+			return
+		}
 		if httpError, ok := err.(httperrors.Error); ok {
 			httpError.WriteTo(rw)
 			return
 		}
-		http.Error(rw, err.Error(), 500)
+		http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 	if !e.SkipContext {
@@ -207,37 +318,127 @@ func (e *Event) Handle(next http.Handler) http.Handler {
 	if e.ContextKey == nil {
 		e.ContextKey = DefaultContextKey
 	}
-	getEvent := e.EventFromRequest()
+	getEvent := e.EventFromRequestWithEnvironment()
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		paddleEvent, err := getEvent(req)
+		paddleEvent, env, err := getEvent(req)
 		if err != nil {
 			e.onError(next, rw, req, err)
 			return
 		}
+		if env != "" {
+			req = req.WithContext(events.WithEnvironment(req.Context(), env))
+		}
 		if !e.SkipContext {
 			req = req.WithContext(context.WithValue(req.Context(), e.ContextKey, paddleEvent))
 		}
-		next.ServeHTTP(rw, req)
+		if e.Freshness == nil {
+			next.ServeHTTP(rw, req)
+			return
+		}
+		sw := response.NewWriter(rw)
+		next.ServeHTTP(sw, req)
+		if sw.Failed() {
+			e.Freshness.Forget(paddleEvent)
+			return
+		}
+		e.Freshness.Accept(paddleEvent)
 	})
 }
 
+func (e *Event) forget(ev events.Event) {
+	if e.Freshness != nil {
+		e.Freshness.Forget(ev)
+	}
+}
+
 func (e *Event) EventFromRequest() func(req *http.Request) (events.Event, error) {
-	verify := e.Verifier != nil
+	getEvent := e.EventFromRequestWithEnvironment()
 	return func(req *http.Request) (events.Event, error) {
+		ev, _, err := getEvent(req)
+		return ev, err
+	}
+}
+
+// EventFromRequestWithEnvironment is like EventFromRequest, but also
+// returns environment which signed the event if Verifier is
+// events.EnvironmentVerifier. If event is taken from request context,
+// so is its environment.
+func (e *Event) EventFromRequestWithEnvironment() func(req *http.Request) (events.Event, events.Environment, error) {
+	verify := e.Verifier != nil
+	envVerifier, _ := e.Verifier.(events.EnvironmentVerifier)
+	verifyFields := verify && e.VerifyFields
+	registry := e.Registry
+	if registry == nil {
+		registry = events.DefaultRegistry
+	}
+	return func(req *http.Request) (events.Event, events.Environment, error) {
 		if !e.SkipContext {
 			if ev, ok := req.Context().Value(e.ContextKey).(events.Event); ok && ev != nil {
-				return ev, nil
+				env, _ := events.EnvironmentFromContext(req.Context())
+				return ev, env, nil
+			}
+		}
+		_, replayed := journal.ReplayOffset(req.Context())
+		if e.IPAllowlist != nil && !replayed {
+			if err := e.IPAllowlist.Allow(req); err != nil {
+				return nil, "", err
 			}
 		}
-		ev, err := readEventFromRequest(req, e.CopyBody)
+		ev, fields, err := readEventFromRequest(req, readOptions{
+			registry:     registry,
+			copyBody:     e.CopyBody || e.Journal != nil,
+			withFields:   verifyFields,
+			unknownAsRaw: e.UnknownAsRaw,
+			maxBodyBytes: e.MaxBodyBytes,
+		})
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
+		var env events.Environment
 		if verify {
-			if err := e.Verifier.Verify(ev); err != nil {
-				return nil, err
+			var signed events.Event = ev
+			if verifyFields {
+				signed = fields
+			}
+			if envVerifier != nil {
+				env, err = envVerifier.VerifyEnvironment(signed)
+			} else {
+				err = e.Verifier.Verify(signed)
+			}
+			if err != nil {
+				return nil, "", Error{Class: ErrSignature, Err: err, AlertName: ev.GetAlertName()}
+			}
+		}
+		if !replayed {
+			if e.Freshness != nil {
+				if err := e.Freshness.Check(ev); err != nil {
+					return nil, "", err
+				}
+			}
+			if e.Journal != nil {
+				if err := e.appendToJournal(req, ev); err != nil {
+					e.forget(ev)
+					return nil, "", err
+				}
 			}
 		}
-		return ev, nil
+		return ev, env, nil
+	}
+}
+
+// appendToJournal stores body of req, which must have been copied
+// by readEventFromRequest, in journal.
+func (e *Event) appendToJournal(req *http.Request, ev events.Event) error {
+	body := req.Body.(*buffer)
+	_, err := e.Journal.Append(&journal.Entry{
+		ReceivedAt:  time.Now(),
+		AlertName:   ev.GetAlertName(),
+		ContentType: req.Header.Get(mime.ContentTypeHeader),
+		Header:      req.Header.Clone(),
+		Body:        body.Bytes(),
+	})
+	if err != nil {
+		return Error{Class: ErrJournal, Err: err, AlertName: ev.GetAlertName()}
 	}
+	return nil
 }
@@ -3,15 +3,23 @@ package middleware
 import (
 	"bytes"
 	"context"
+	"crypto/rsa"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dennor/go-paddle/events"
 	"github.com/dennor/go-paddle/events/alerts"
 	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/httperrors"
+	"github.com/dennor/go-paddle/journal"
 	"github.com/dennor/go-paddle/mime"
+	"github.com/dennor/go-paddle/signature"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -34,12 +42,161 @@ func TestEventNameFromURLEncoded(t *testing.T) {
 			query:        []byte("beforeParameter=beforeParameter&alert_name=alertName&other_parameter=otherParameter"),
 			expectedName: "alertName",
 		},
+		{
+			query:        []byte("a=1&"),
+			expectedName: "",
+		},
+		{
+			query:        []byte("a=1&alert_na"),
+			expectedName: "",
+		},
 	}
 	for _, tt := range data {
 		assert.Equal(tt.expectedName, eventNameFromURLEncoded(tt.query), "query was %s", string(tt.query))
 	}
 }
 
+func TestEventNameFromJSON(t *testing.T) {
+	assert := assert.New(t)
+	data := []struct {
+		body         []byte
+		expectedName string
+	}{
+		{
+			body:         []byte(`{"alert_name":"alertName"}`),
+			expectedName: "alertName",
+		},
+		{
+			body:         []byte(`{"before_parameter":"beforeParameter","alert_name":"alertName","other_parameter":"otherParameter"}`),
+			expectedName: "alertName",
+		},
+		{
+			body:         []byte(`{"other_parameter":"otherParameter"}`),
+			expectedName: "",
+		},
+		{
+			body:         []byte(`not json`),
+			expectedName: "",
+		},
+	}
+	for _, tt := range data {
+		assert.Equal(tt.expectedName, eventNameFromJSON(tt.body), "body was %s", string(tt.body))
+	}
+}
+
+type customEvent struct {
+	AlertName string `json:"alert_name"`
+	Value     string `json:"value"`
+}
+
+func (c *customEvent) Serialize() ([]byte, error) { return nil, nil }
+func (c *customEvent) Signature() ([]byte, error) { return nil, nil }
+func (c *customEvent) GetAlertName() string       { return c.AlertName }
+func (c *customEvent) GetAlertID() (int, bool)    { return 0, false }
+func (c *customEvent) GetEventTime() time.Time    { return time.Time{} }
+func (c *customEvent) GetPassthrough() string     { return "" }
+func (c *customEvent) GetEmail() string           { return "" }
+func (c *customEvent) GetCheckoutID() string      { return "" }
+
+func TestAlertName(t *testing.T) {
+	assert := assert.New(t)
+	b := []byte("a=b&alert_name=transfer_paid")
+	name := AlertName(mime.ApplicationForm+"; charset=utf-8", b)
+	assert.Equal("transfer_paid", name)
+	copy(b, "xxxxxxxxxxxxxxxxxxxxxxxxxxxx")
+	assert.Equal("transfer_paid", name, "name must not share memory with body")
+	assert.Equal("transfer_paid", AlertName(mime.ApplicationJSON, []byte(`{"alert_name":"transfer_paid"}`)))
+	assert.Equal("", AlertName("text/plain", []byte("alert_name=transfer_paid")))
+	assert.Equal("", AlertName(mime.ApplicationForm, []byte("a=1&")))
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("DefaultRegistryHasBuiltinEvents", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Equal([]string{
+			"high_risk_transaction_created",
+			"high_risk_transaction_updated",
+			"locker_processed",
+			"new_audience_member",
+			"payment_dispute_closed",
+			"payment_dispute_created",
+			"payment_refunded",
+			"payment_succeeded",
+			"subscription_cancelled",
+			"subscription_created",
+			"subscription_payment_failed",
+			"subscription_payment_refunded",
+			"subscription_payment_succeeded",
+			"subscription_updated",
+			"transfer_created",
+			"transfer_paid",
+			"update_audience_member",
+		}, events.DefaultRegistry.Names())
+	})
+
+	t.Run("CustomEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		registry := events.DefaultRegistry.Clone()
+		registry.Register("custom_event", func() events.Event { return new(customEvent) })
+		newReq := func() *http.Request {
+			req := &http.Request{
+				Header: make(http.Header),
+			}
+			req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+			req.Body = ioutil.NopCloser(bytes.NewReader([]byte("alert_name=custom_event&value=v")))
+			return req
+		}
+		ev, err := (&Event{EventConfig: EventConfig{
+			Registry:    registry,
+			SkipContext: true,
+		}}).EventFromRequest()(newReq())
+		assert.NoError(err)
+		assert.Equal(&customEvent{AlertName: "custom_event", Value: "v"}, ev)
+		_, err = (&Event{EventConfig: EventConfig{
+			SkipContext: true,
+		}}).EventFromRequest()(newReq())
+		assert.Error(err)
+	})
+
+	t.Run("UnknownAsRaw", func(t *testing.T) {
+		d := test.Sign(map[string]string{
+			"alert_id":   "11",
+			"alert_name": "brand_new_alert",
+			"event_time": "2019-04-15 07:37:53",
+			"new_field":  "value",
+		})
+		for _, tt := range []struct {
+			name        string
+			contentType string
+			body        string
+		}{
+			{"Form", mime.ApplicationForm, d.URL},
+			{"JSON", mime.ApplicationJSON, d.JSON},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+				req := &http.Request{
+					Header: make(http.Header),
+				}
+				req.Header.Set(mime.ContentTypeHeader, tt.contentType)
+				req.Body = ioutil.NopCloser(bytes.NewReader([]byte(tt.body)))
+				ev, err := (&Event{EventConfig: EventConfig{
+					Verifier: events.RSAVerifier(signature.RSA{
+						PublicKey: &test.Key.PublicKey,
+					}),
+					SkipContext:  true,
+					UnknownAsRaw: true,
+				}}).EventFromRequest()(req)
+				assert.NoError(err)
+				assert.Equal(&events.Raw{
+					AlertName: "brand_new_alert",
+					Fields:    events.Fields(d.M),
+				}, ev)
+			})
+		}
+	})
+}
+
 var benchmarkEventNameDoNotOptimize string
 
 func BenchmarkEventNameFromURLEncoded(b *testing.B) {
@@ -227,6 +384,125 @@ func TestEventMiddleware(t *testing.T) {
 			(&Event{}).Handle(mockHandler).ServeHTTP(mockWriter, req)
 		}
 	})
+
+	t.Run("CallsNextWithJSONEvent", func(t *testing.T) {
+		req := &http.Request{
+			Header: make(http.Header),
+		}
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationJSON+"; charset=utf-8")
+		req.Body = ioutil.NopCloser(bytes.NewReader([]byte(`{"alert_name":"transfer_paid","currency":"PLN"}`)))
+		expectedReq := new(http.Request)
+		*expectedReq = *req
+		expectedReq = expectedReq.WithContext(context.WithValue(expectedReq.Context(), DefaultContextKey, &alerts.TransferPaid{
+			AlertName: "transfer_paid",
+			Currency:  "PLN",
+		}))
+		mockWriter := new(mockResponseWriter)
+		mockHandler := new(mockHandler)
+		mockHandler.On("ServeHTTP", mockWriter, expectedReq).Once()
+		(&Event{}).Handle(mockHandler).ServeHTTP(mockWriter, req)
+		mockHandler.AssertExpectations(t)
+	})
+
+	t.Run("VerifiesJSONEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		d := test.Sign(map[string]string{
+			"alert_name": "transfer_paid",
+			"amount":     "1.23",
+			"currency":   "PLN",
+			"event_time": "2019-04-15 07:37:53",
+			"payout_id":  "2",
+			"status":     "closed",
+		})
+		req := &http.Request{
+			Header: make(http.Header),
+		}
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationJSON)
+		req.Body = ioutil.NopCloser(bytes.NewReader([]byte(d.JSON)))
+		ev, err := (&Event{EventConfig: EventConfig{
+			Verifier: events.RSAVerifier(signature.RSA{
+				PublicKey: &test.Key.PublicKey,
+			}),
+			SkipContext: true,
+		}}).EventFromRequest()(req)
+		assert.NoError(err)
+		assert.IsType(&alerts.TransferPaid{}, ev)
+	})
+
+	t.Run("RejectsRepeatedFields", func(t *testing.T) {
+		assert := assert.New(t)
+		d := test.Sign(map[string]string{
+			"alert_name": "transfer_paid",
+			"amount":     "1.23",
+			"currency":   "PLN",
+			"event_time": "2019-04-15 07:37:53",
+			"payout_id":  "2",
+			"status":     "closed",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(d.URL+"&amount=9999")))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			t.Fatal("next must not be called")
+		})
+		e := &Event{EventConfig: EventConfig{
+			Verifier: events.RSAVerifier(signature.RSA{
+				PublicKey: &test.Key.PublicKey,
+			}),
+			VerifyFields: true,
+		}}
+		_, err := e.EventFromRequest()(req)
+		assert.True(errors.Is(err, ErrDecode))
+		assert.True(errors.Is(err, events.ErrRepeatedField))
+		req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(d.URL+"&amount=9999")))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		rw := httptest.NewRecorder()
+		e.Handle(next).ServeHTTP(rw, req)
+		assert.Equal(http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("VerifiesFields", func(t *testing.T) {
+		d := test.Sign(map[string]string{
+			"alert_name":     "transfer_paid",
+			"amount":         "1.230",
+			"currency":       "PLN",
+			"event_time":     "2019-04-15 07:37:53",
+			"new_paddle_key": "value unknown to this module",
+			"payout_id":      "2",
+			"status":         "closed",
+		})
+		for _, tt := range []struct {
+			name        string
+			contentType string
+			body        string
+		}{
+			{"Form", mime.ApplicationForm, d.URL},
+			{"JSON", mime.ApplicationJSON, d.JSON},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+				newReq := func() *http.Request {
+					req := &http.Request{
+						Header: make(http.Header),
+					}
+					req.Header.Set(mime.ContentTypeHeader, tt.contentType)
+					req.Body = ioutil.NopCloser(bytes.NewReader([]byte(tt.body)))
+					return req
+				}
+				config := EventConfig{
+					Verifier: events.RSAVerifier(signature.RSA{
+						PublicKey: &test.Key.PublicKey,
+					}),
+					SkipContext: true,
+				}
+				_, err := (&Event{EventConfig: config}).EventFromRequest()(newReq())
+				assert.Error(err)
+				config.VerifyFields = true
+				ev, err := (&Event{EventConfig: config}).EventFromRequest()(newReq())
+				assert.NoError(err)
+				assert.IsType(&alerts.TransferPaid{}, ev)
+			})
+		}
+	})
 }
 
 type benchHandler struct{}
@@ -378,3 +654,276 @@ func BenchmarkEventMidleware(b *testing.B) {
 		})
 	}
 }
+
+type memoryJournal struct {
+	entries []journal.Entry
+	err     error
+}
+
+func (m *memoryJournal) Append(e *journal.Entry) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	e.Offset = int64(len(m.entries))
+	m.entries = append(m.entries, *e)
+	return e.Offset, nil
+}
+
+func TestJournal(t *testing.T) {
+	d := test.Sign(map[string]string{
+		"alert_name": "transfer_paid",
+		"amount":     "1.23",
+		"currency":   "PLN",
+		"event_time": "2019-04-15 07:37:53",
+		"payout_id":  "2",
+		"status":     "closed",
+	})
+	newReq := func(body string) *http.Request {
+		req := &http.Request{
+			Header: make(http.Header),
+		}
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		req.Body = ioutil.NopCloser(bytes.NewReader([]byte(body)))
+		return req.WithContext(context.Background())
+	}
+	newEvent := func(j Journal) *Event {
+		return &Event{EventConfig: EventConfig{
+			Verifier: events.RSAVerifier(signature.RSA{
+				PublicKey: &test.Key.PublicKey,
+			}),
+			SkipContext: true,
+			Journal:     j,
+		}}
+	}
+
+	t.Run("AppendsVerifiedEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		j := &memoryJournal{}
+		req := newReq(d.URL)
+		req.Header.Set("X-Request-Id", "1")
+		_, err := newEvent(j).EventFromRequest()(req)
+		assert.NoError(err)
+		if assert.Len(j.entries, 1) {
+			e := j.entries[0]
+			assert.Equal("transfer_paid", e.AlertName)
+			assert.Equal(mime.ApplicationForm, e.ContentType)
+			assert.Equal("1", e.Header.Get("X-Request-Id"))
+			assert.Equal(d.URL, string(e.Body))
+			assert.False(e.ReceivedAt.IsZero())
+		}
+		b, err := ioutil.ReadAll(req.Body)
+		assert.NoError(err)
+		assert.Equal(d.URL, string(b), "body must be available to next handler")
+	})
+
+	t.Run("SkipsUnverifiedEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		j := &memoryJournal{}
+		_, err := newEvent(j).EventFromRequest()(newReq("alert_name=transfer_paid&p_signature=invalid"))
+		assert.Error(err)
+		assert.Empty(j.entries)
+	})
+
+	t.Run("SkipsReplayedEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		j := &memoryJournal{}
+		req := newReq(d.URL)
+		_, err := newEvent(j).EventFromRequest()(req.WithContext(journal.WithReplay(req.Context(), 3)))
+		assert.NoError(err)
+		assert.Empty(j.entries)
+	})
+
+	t.Run("AppendFails", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			t.Fatal("next must not be called")
+		})
+		newEvent(&memoryJournal{err: errors.New("disk full")}).Handle(next).ServeHTTP(rw, newReq(d.URL))
+		assert.Equal(http.StatusInternalServerError, rw.Code)
+	})
+}
+
+func TestEnvironment(t *testing.T) {
+	d := test.Sign(map[string]string{
+		"alert_name": "transfer_paid",
+		"amount":     "1.23",
+		"currency":   "PLN",
+		"event_time": "2019-04-15 07:37:53",
+		"payout_id":  "2",
+		"status":     "closed",
+	})
+	newReq := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(d.URL)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+	keys := events.EnvironmentKeys{
+		events.EnvironmentSandbox: events.RSAVerifier{PublicKey: &test.Key.PublicKey},
+	}
+
+	t.Run("EventFromRequest", func(t *testing.T) {
+		assert := assert.New(t)
+		e := &Event{EventConfig: EventConfig{Verifier: keys, SkipContext: true}}
+		ev, env, err := e.EventFromRequestWithEnvironment()(newReq())
+		assert.NoError(err)
+		assert.IsType(&alerts.TransferPaid{}, ev)
+		assert.Equal(events.EnvironmentSandbox, env)
+	})
+
+	t.Run("PlainVerifier", func(t *testing.T) {
+		assert := assert.New(t)
+		e := &Event{EventConfig: EventConfig{
+			Verifier:    events.RSAVerifier{PublicKey: &test.Key.PublicKey},
+			SkipContext: true,
+		}}
+		_, env, err := e.EventFromRequestWithEnvironment()(newReq())
+		assert.NoError(err)
+		assert.Empty(env)
+	})
+
+	t.Run("Handle", func(t *testing.T) {
+		assert := assert.New(t)
+		var env events.Environment
+		next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			env, _ = events.EnvironmentFromContext(req.Context())
+		})
+		e := &Event{EventConfig: EventConfig{Verifier: keys}}
+		e.Handle(next).ServeHTTP(httptest.NewRecorder(), newReq())
+		assert.Equal(events.EnvironmentSandbox, env)
+	})
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	body := "alert_name=transfer_paid&amount=1.23"
+	newReq := func(ctx context.Context) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req.WithContext(ctx)
+	}
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		e := &Event{EventConfig: EventConfig{MaxBodyBytes: int64(len(body)) - 1}}
+		e.Handle(next).ServeHTTP(rw, newReq(context.Background()))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+	})
+
+	t.Run("AtLimit", func(t *testing.T) {
+		e := &Event{EventConfig: EventConfig{MaxBodyBytes: int64(len(body)), SkipContext: true}}
+		_, err := e.EventFromRequest()(newReq(context.Background()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("ContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		e := &Event{EventConfig: EventConfig{SkipContext: true}}
+		_, err := e.EventFromRequest()(newReq(ctx))
+		assert.True(t, errors.Is(err, ErrBodyRead))
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("OversizedBufferNotPooled", func(t *testing.T) {
+		p := newBodyBufferPool()
+		buf := p.Get()
+		buf.Grow(2 * maxPooledBufferSize)
+		p.Put(buf)
+		assert.True(t, p.Get().Cap() <= maxPooledBufferSize)
+	})
+}
+
+func TestErrors(t *testing.T) {
+	d := test.Sign(map[string]string{
+		"alert_name": "transfer_paid",
+		"amount":     "1.23",
+		"currency":   "PLN",
+		"event_time": "2019-04-15 07:37:53",
+		"payout_id":  "2",
+		"status":     "closed",
+	})
+	e := &Event{EventConfig: EventConfig{
+		Verifier: events.RSAVerifier(signature.RSA{
+			PublicKey: &test.Key.PublicKey,
+		}),
+	}}
+	for _, tt := range []struct {
+		name        string
+		contentType string
+		body        string
+		class       error
+		status      int
+	}{
+		{"UnsupportedMediaType", "text/plain", d.URL, ErrUnsupportedMediaType, http.StatusUnsupportedMediaType},
+		{"UnknownAlert", mime.ApplicationForm, "alert_name=brand_new_alert", ErrUnknownAlert, http.StatusBadRequest},
+		{"Decode", mime.ApplicationJSON, `{"alert_name":"transfer_paid","amount":[]}`, ErrDecode, http.StatusBadRequest},
+		{"Signature", mime.ApplicationForm, "alert_name=transfer_paid&p_signature=aW52YWxpZA%3D%3D", ErrSignature, http.StatusForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			newReq := func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tt.body)))
+				req.Header.Set(mime.ContentTypeHeader, tt.contentType)
+				return req
+			}
+			_, err := e.EventFromRequest()(newReq())
+			assert.True(errors.Is(err, tt.class), "%v", err)
+			assert.Equal(tt.status, httperrors.StatusCode(err))
+			rw := httptest.NewRecorder()
+			e.Handle(http.NotFoundHandler()).ServeHTTP(rw, newReq())
+			assert.Equal(tt.status, rw.Code)
+			assert.Equal(tt.class.Error()+"\n", rw.Body.String(), "details must not be leaked")
+		})
+	}
+
+	t.Run("VerificationError", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=transfer_paid&p_signature=aW52YWxpZA%3D%3D")))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		_, err := e.EventFromRequest()(req)
+		var verr signature.VerificationError
+		assert.True(t, errors.As(err, &verr))
+		assert.True(t, errors.Is(err, rsa.ErrVerification))
+	})
+
+	t.Run("BodyReadNotLeaked", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", ioutil.NopCloser(errReader{}))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		e.Handle(http.NotFoundHandler()).ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		assert.NotContains(t, rw.Body.String(), "connection reset")
+	})
+}
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestWriteProblem(t *testing.T) {
+	assert := assert.New(t)
+	var handled error
+	e := &Event{EventConfig: EventConfig{
+		Verifier: events.RSAVerifier(signature.RSA{
+			PublicKey: &test.Key.PublicKey,
+		}),
+		ErrorHandler: func(rw http.ResponseWriter, req *http.Request, err error) {
+			handled = err
+			WriteProblem(rw, req, err)
+		},
+	}}
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=transfer_paid&p_signature=aW52YWxpZA%3D%3D")))
+	req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+	rw := httptest.NewRecorder()
+	e.Handle(http.NotFoundHandler()).ServeHTTP(rw, req)
+	assert.True(errors.Is(handled, ErrSignature))
+	assert.Equal(http.StatusForbidden, rw.Code)
+	assert.Equal(mime.ApplicationProblemJSON, rw.Header().Get(mime.ContentTypeHeader))
+	assert.JSONEq(`{
+		"type": "about:blank",
+		"title": "Forbidden",
+		"status": 403,
+		"detail": "invalid signature",
+		"alert_name": "transfer_paid"
+	}`, rw.Body.String())
+}
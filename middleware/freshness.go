@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+)
+
+var (
+	// ErrStaleEvent is reported by ReplayError for events with event_time
+	// older than Freshness.MaxAge or without event_time.
+	ErrStaleEvent = errors.New("event is too old")
+	// ErrReplayedEvent is reported by ReplayError for events whose
+	// signature was already accepted.
+	ErrReplayedEvent = errors.New("event was already accepted")
+	// ErrEventInFlight is reported by ReplayError for events whose
+	// signature was checked, but not yet accepted or forgotten.
+	ErrEventInFlight = errors.New("event is being handled")
+)
+
+// ReplayError is returned for events rejected by Freshness, Err is
+// ErrStaleEvent, ErrReplayedEvent or ErrEventInFlight. Stale events are
+// answered with 403 Forbidden, replayed ones with Freshness.ReplayStatus
+// and events in flight with 409 Conflict, so paddle delivers them again.
+type ReplayError struct {
+	Err       error
+	AlertName string
+	EventTime time.Time
+	status    int
+}
+
+func (r ReplayError) Error() string {
+	return fmt.Sprintf("%s: %v", r.AlertName, r.Err)
+}
+
+func (r ReplayError) Unwrap() error {
+	return r.Err
+}
+
+func (r ReplayError) Status() int {
+	if r.status != 0 {
+		return r.status
+	}
+	return http.StatusForbidden
+}
+
+func (r ReplayError) Detail() string {
+	return r.Err.Error()
+}
+
+// WriteTo writes status of r, body is written only for error statuses.
+func (r ReplayError) WriteTo(rw http.ResponseWriter) {
+	if status := r.Status(); status < http.StatusBadRequest {
+		rw.WriteHeader(status)
+		return
+	}
+	http.Error(rw, r.Detail(), r.Status())
+}
+
+// Freshness rejects verified events whose event_time is older than MaxAge
+// and events whose signature was seen before. At most CacheSize
+// signatures are remembered, the oldest are forgotten first, so CacheSize
+// should exceed number of alerts received within MaxAge. Zero MaxAge or
+// CacheSize disables respective check.
+//
+// Signature of checked event is in flight until it is passed to Accept
+// after its handling succeeded, or to Forget after it failed. Event.Handle
+// and router.Router do it depending on response status, callers of
+// EventFromRequest must do it themselves. Events in flight are rejected
+// with 409 Conflict, so redelivery which arrives while the first delivery
+// is still handled is retried by paddle later.
+//
+// Paddle retries failed alerts with their original event_time and
+// signature, MaxAge shorter than retry period drops late retries. It also
+// redelivers alerts whose acknowledgement it did not receive, e.g. after
+// a timeout, so accepted events are acknowledged with 200 OK by default,
+// which stops the redelivery without running handlers again.
+// Freshness is safe for concurrent use.
+type Freshness struct {
+	MaxAge    time.Duration
+	CacheSize int
+	// ReplayStatus is status events which were already accepted are
+	// answered with, http.StatusOK is used if zero.
+	ReplayStatus int
+	// OnReject, if set, is called with every rejected event,
+	// e.g. to alert operators about replay attempts.
+	OnReject func(ReplayError)
+	// Now returns current time, time.Now is used if nil.
+	Now func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]int
+	ring []signatureState
+	next int
+}
+
+// signatureState is signature remembered by Freshness.
+type signatureState struct {
+	key      string
+	accepted bool
+}
+
+func NewFreshness(maxAge time.Duration, cacheSize int) *Freshness {
+	return &Freshness{MaxAge: maxAge, CacheSize: cacheSize}
+}
+
+func (f *Freshness) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+// Check returns ReplayError if e is stale, was already accepted or is
+// in flight, otherwise e is remembered as in flight.
+func (f *Freshness) Check(e events.Event) error {
+	if f.MaxAge > 0 {
+		t := e.GetEventTime()
+		if t.IsZero() || f.now().Sub(t) > f.MaxAge {
+			return f.reject(ReplayError{Err: ErrStaleEvent, AlertName: e.GetAlertName(), EventTime: t})
+		}
+	}
+	if f.CacheSize <= 0 {
+		return nil
+	}
+	sig, err := e.Signature()
+	if err != nil {
+		return err
+	}
+	if seen, accepted := f.remember(string(sig)); seen {
+		err := ReplayError{Err: ErrEventInFlight, AlertName: e.GetAlertName(), EventTime: e.GetEventTime(), status: http.StatusConflict}
+		if accepted {
+			err.Err, err.status = ErrReplayedEvent, f.ReplayStatus
+			if err.status == 0 {
+				err.status = http.StatusOK
+			}
+		}
+		return f.reject(err)
+	}
+	return nil
+}
+
+func (f *Freshness) reject(err ReplayError) error {
+	if f.OnReject != nil {
+		f.OnReject(err)
+	}
+	return err
+}
+
+// remember adds key to signatures in flight, it reports whether key
+// was already there and whether it was accepted.
+func (f *Freshness) remember(key string) (seen, accepted bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i, ok := f.seen[key]; ok {
+		return true, f.ring[i].accepted
+	}
+	if f.seen == nil {
+		f.seen = make(map[string]int, f.CacheSize)
+	}
+	if len(f.ring) < f.CacheSize {
+		f.ring = append(f.ring, signatureState{key: key})
+		f.seen[key] = len(f.ring) - 1
+		return false, false
+	}
+	if old := f.ring[f.next].key; f.seen[old] == f.next {
+		delete(f.seen, old)
+	}
+	f.ring[f.next] = signatureState{key: key}
+	f.seen[key] = f.next
+	f.next = (f.next + 1) % f.CacheSize
+	return false, false
+}
+
+// Accept marks e, which is in flight, as accepted, so its redeliveries
+// are answered with ReplayStatus. Events which are not remembered,
+// e.g. forgotten ones, are left alone.
+func (f *Freshness) Accept(e events.Event) {
+	sig, err := e.Signature()
+	if err != nil {
+		return
+	}
+	f.mu.Lock()
+	if i, ok := f.seen[string(sig)]; ok {
+		f.ring[i].accepted = true
+	}
+	f.mu.Unlock()
+}
+
+// Forget removes e from events in flight and accepted events.
+func (f *Freshness) Forget(e events.Event) {
+	sig, err := e.Signature()
+	if err != nil {
+		return
+	}
+	f.mu.Lock()
+	delete(f.seen, string(sig))
+	f.mu.Unlock()
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/dennor/go-paddle/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2019, 4, 15, 7, 40, 0, 0, time.UTC)
+	newFreshness := func(maxAge time.Duration, cacheSize int) *Freshness {
+		f := NewFreshness(maxAge, cacheSize)
+		f.Now = func() time.Time { return now }
+		return f
+	}
+	signed := func(id string) events.Fields {
+		return events.Fields(test.Sign(map[string]string{
+			"alert_name": "transfer_paid",
+			"amount":     "1.23",
+			"currency":   "PLN",
+			"event_time": "2019-04-15 07:37:53",
+			"payout_id":  id,
+			"status":     "closed",
+		}).M)
+	}
+
+	t.Run("Stale", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.NoError(newFreshness(5*time.Minute, 0).Check(signed("1")))
+		err := newFreshness(time.Minute, 0).Check(signed("1"))
+		var replayErr ReplayError
+		if assert.True(errors.As(err, &replayErr)) {
+			assert.Equal("transfer_paid", replayErr.AlertName)
+			assert.Equal(time.Date(2019, 4, 15, 7, 37, 53, 0, time.UTC), replayErr.EventTime)
+		}
+		assert.True(errors.Is(err, ErrStaleEvent))
+		assert.True(errors.Is(newFreshness(time.Hour, 0).Check(events.Fields{"alert_name": "transfer_paid"}), ErrStaleEvent))
+	})
+
+	t.Run("Replayed", func(t *testing.T) {
+		assert := assert.New(t)
+		f := newFreshness(0, 2)
+		assert.NoError(f.Check(signed("1")))
+		err := f.Check(signed("1"))
+		assert.True(errors.Is(err, ErrEventInFlight))
+		assert.Equal(http.StatusConflict, err.(ReplayError).Status())
+		f.Accept(signed("1"))
+		assert.True(errors.Is(f.Check(signed("1")), ErrReplayedEvent))
+		f.Forget(signed("1"))
+		f.Accept(signed("1"))
+		assert.NoError(f.Check(signed("1")), "forgotten signature must not be accepted")
+		assert.NoError(f.Check(signed("2")))
+		assert.NoError(f.Check(signed("3")))
+		assert.NoError(f.Check(signed("1")), "oldest signature must be evicted")
+		assert.Error(f.Check(signed("3")))
+	})
+
+	t.Run("Handle", func(t *testing.T) {
+		assert := assert.New(t)
+		body := test.Sign(map[string]string{
+			"alert_name": "transfer_paid",
+			"amount":     "1.23",
+			"currency":   "PLN",
+			"event_time": "2019-04-15 07:37:53",
+			"payout_id":  "2",
+			"status":     "closed",
+		}).URL
+		status := http.StatusInternalServerError
+		calls := 0
+		next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			calls++
+			rw.WriteHeader(status)
+		})
+		freshness := newFreshness(5*time.Minute, 10)
+		var rejected []ReplayError
+		freshness.OnReject = func(err ReplayError) { rejected = append(rejected, err) }
+		handler := (&Event{EventConfig: EventConfig{
+			Verifier:  events.RSAVerifier(signature.RSA{PublicKey: &test.Key.PublicKey}),
+			Freshness: freshness,
+		}}).Handle(next)
+		serve := func() int {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+			return rw.Code
+		}
+		assert.Equal(http.StatusInternalServerError, serve())
+		status = http.StatusOK
+		assert.Equal(http.StatusOK, serve(), "failed event must be forgotten")
+		assert.Equal(http.StatusOK, serve(), "replay must be acknowledged")
+		assert.Equal(2, calls, "replay must not reach handler")
+		if assert.Len(rejected, 1) {
+			assert.True(errors.Is(rejected[0], ErrReplayedEvent))
+		}
+
+		freshness.ReplayStatus = http.StatusConflict
+		assert.Equal(http.StatusConflict, serve())
+		assert.Equal(2, calls)
+	})
+
+	t.Run("ConcurrentRedelivery", func(t *testing.T) {
+		assert := assert.New(t)
+		body := test.Sign(map[string]string{
+			"alert_name": "transfer_paid",
+			"amount":     "1.23",
+			"currency":   "PLN",
+			"event_time": "2019-04-15 07:37:53",
+			"payout_id":  "3",
+			"status":     "closed",
+		}).URL
+		started, release := make(chan struct{}), make(chan struct{})
+		var calls int32
+		next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(started)
+				<-release
+				rw.WriteHeader(http.StatusInternalServerError)
+			}
+		})
+		handler := (&Event{EventConfig: EventConfig{
+			Verifier:  events.RSAVerifier(signature.RSA{PublicKey: &test.Key.PublicKey}),
+			Freshness: newFreshness(5*time.Minute, 10),
+		}}).Handle(next)
+		serve := func() int {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+			return rw.Code
+		}
+		first := make(chan int)
+		go func() { first <- serve() }()
+		<-started
+		assert.Equal(http.StatusConflict, serve(), "redelivery of event in flight must be retried")
+		close(release)
+		assert.Equal(http.StatusInternalServerError, <-first)
+		assert.Equal(http.StatusOK, serve(), "retry of failed event must reach handler")
+		assert.Equal(int32(2), atomic.LoadInt32(&calls))
+	})
+}
@@ -4,4 +4,6 @@ const (
 	ContentTypeHeader = "content-type"
 	ApplicationJSON   = "application/json"
 	ApplicationForm   = "application/x-www-form-urlencoded"
+	// ApplicationProblemJSON is media type of RFC 7807 problem details.
+	ApplicationProblemJSON = "application/problem+json"
 )
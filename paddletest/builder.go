@@ -0,0 +1,101 @@
+package paddletest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/alerts"
+	"github.com/dennor/go-paddle/events/types"
+	"github.com/dennor/go-paddle/middleware"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/dennor/go-paddle/sim"
+)
+
+// builder holds unsigned fields of alert, it is embedded in typed builders.
+type builder struct {
+	fields events.Fields
+}
+
+func newBuilder(alertName string) builder {
+	f, ok := sim.Defaults(alertName)
+	if !ok {
+		panic("paddletest: no defaults for " + alertName)
+	}
+	return builder{fields: f}
+}
+
+func (b *builder) set(key, value string) {
+	b.fields[key] = value
+}
+
+func (b *builder) setInt(key string, v int) {
+	b.set(key, strconv.Itoa(v))
+}
+
+func (b *builder) setDatetime(key string, t time.Time) {
+	b.set(key, t.UTC().Format(types.DatetimeFormat))
+}
+
+func (b *builder) setDate(key string, t time.Time) {
+	b.set(key, t.UTC().Format(types.DateFormat))
+}
+
+// setPhpBool sets value the way php casts bool to string in numeric context.
+func (b *builder) setPhpBool(key string, v bool) {
+	if v {
+		b.set(key, "1")
+	} else {
+		b.set(key, "0")
+	}
+}
+
+func (b *builder) setBool(key string, v bool) {
+	b.set(key, strconv.FormatBool(v))
+}
+
+func (b *builder) setProducts(key string, ids []int64) {
+	b.set(key, alerts.AudienceMemberProducts(ids).String())
+}
+
+// Fields returns copy of alert fields signed with PrivateKey.
+func (b builder) Fields() events.Fields {
+	f := make(events.Fields, len(b.fields)+1)
+	for k, v := range b.fields {
+		f[k] = v
+	}
+	if err := (sim.Signer{Key: PrivateKey}).Sign(f); err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Body returns signed alert as application/x-www-form-urlencoded body.
+func (b builder) Body() string {
+	return sim.Encode(b.Fields())
+}
+
+// Request returns signed alert as POST request to "/", ready to be
+// passed to http.Handler.
+func (b builder) Request() *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(b.Body())))
+	req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+	return req
+}
+
+// RequestTo returns signed alert as POST request to url, ready to be sent
+// with http.Client.
+func (b builder) RequestTo(url string) (*http.Request, error) {
+	return sim.NewRequest(url, b.Fields())
+}
+
+// event decodes signed alert the same way router does.
+func (b builder) event() (events.Event, error) {
+	return (&middleware.Event{EventConfig: middleware.EventConfig{
+		Verifier:    Verifier(),
+		SkipContext: true,
+	}}).EventFromRequest()(b.Request())
+}
@@ -0,0 +1,1992 @@
+package paddletest
+
+import (
+	"net"
+	"time"
+
+	"github.com/dennor/go-paddle/events/alerts"
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/shopspring/decimal"
+)
+
+// AlertHighRiskTransactionCreatedBuilder builds alerts.HighRiskTransactionCreated alerts.
+type AlertHighRiskTransactionCreatedBuilder struct {
+	builder
+}
+
+// AlertHighRiskTransactionCreated starts building alerts.HighRiskTransactionCreated with realistic default values.
+func AlertHighRiskTransactionCreated() *AlertHighRiskTransactionCreatedBuilder {
+	return &AlertHighRiskTransactionCreatedBuilder{newBuilder(alerts.HighRiskTransactionCreatedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertHighRiskTransactionCreatedBuilder) Set(key, value string) *AlertHighRiskTransactionCreatedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertHighRiskTransactionCreatedBuilder) Without(key string) *AlertHighRiskTransactionCreatedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertHighRiskTransactionCreatedBuilder) Event() (*alerts.HighRiskTransactionCreated, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.HighRiskTransactionCreated), nil
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithAlertID(v int) *AlertHighRiskTransactionCreatedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithCaseID(v int) *AlertHighRiskTransactionCreatedBuilder {
+	b.setInt("case_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithCheckoutID(v string) *AlertHighRiskTransactionCreatedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithCreatedAt(v time.Time) *AlertHighRiskTransactionCreatedBuilder {
+	b.setDatetime("created_at", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithCustomerEmailAddress(v string) *AlertHighRiskTransactionCreatedBuilder {
+	b.set("customer_email_address", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithCustomerUserID(v int) *AlertHighRiskTransactionCreatedBuilder {
+	b.setInt("customer_user_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithEventTime(v time.Time) *AlertHighRiskTransactionCreatedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithMarketingConsent(v bool) *AlertHighRiskTransactionCreatedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithPassthrough(v string) *AlertHighRiskTransactionCreatedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithProductID(v int) *AlertHighRiskTransactionCreatedBuilder {
+	b.setInt("product_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithRiskScore(v decimal.Decimal) *AlertHighRiskTransactionCreatedBuilder {
+	b.set("risk_score", v.String())
+	return b
+}
+
+func (b *AlertHighRiskTransactionCreatedBuilder) WithStatus(v string) *AlertHighRiskTransactionCreatedBuilder {
+	b.set("status", v)
+	return b
+}
+
+// AlertHighRiskTransactionUpdatedBuilder builds alerts.HighRiskTransactionUpdated alerts.
+type AlertHighRiskTransactionUpdatedBuilder struct {
+	builder
+}
+
+// AlertHighRiskTransactionUpdated starts building alerts.HighRiskTransactionUpdated with realistic default values.
+func AlertHighRiskTransactionUpdated() *AlertHighRiskTransactionUpdatedBuilder {
+	return &AlertHighRiskTransactionUpdatedBuilder{newBuilder(alerts.HighRiskTransactionUpdatedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertHighRiskTransactionUpdatedBuilder) Set(key, value string) *AlertHighRiskTransactionUpdatedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertHighRiskTransactionUpdatedBuilder) Without(key string) *AlertHighRiskTransactionUpdatedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertHighRiskTransactionUpdatedBuilder) Event() (*alerts.HighRiskTransactionUpdated, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.HighRiskTransactionUpdated), nil
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithAlertID(v int) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithCaseID(v int) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setInt("case_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithCheckoutID(v string) *AlertHighRiskTransactionUpdatedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithCreatedAt(v time.Time) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setDatetime("created_at", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithCustomerEmailAddress(v string) *AlertHighRiskTransactionUpdatedBuilder {
+	b.set("customer_email_address", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithCustomerUserID(v int) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setInt("customer_user_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithEventTime(v time.Time) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithMarketingConsent(v bool) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithPassthrough(v string) *AlertHighRiskTransactionUpdatedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithProductID(v int) *AlertHighRiskTransactionUpdatedBuilder {
+	b.setInt("product_id", v)
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithRiskScore(v decimal.Decimal) *AlertHighRiskTransactionUpdatedBuilder {
+	b.set("risk_score", v.String())
+	return b
+}
+
+func (b *AlertHighRiskTransactionUpdatedBuilder) WithStatus(v string) *AlertHighRiskTransactionUpdatedBuilder {
+	b.set("status", v)
+	return b
+}
+
+// AlertLockerProcessedBuilder builds alerts.LockerProcessed alerts.
+type AlertLockerProcessedBuilder struct {
+	builder
+}
+
+// AlertLockerProcessed starts building alerts.LockerProcessed with realistic default values.
+func AlertLockerProcessed() *AlertLockerProcessedBuilder {
+	return &AlertLockerProcessedBuilder{newBuilder(alerts.LockerProcessedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertLockerProcessedBuilder) Set(key, value string) *AlertLockerProcessedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertLockerProcessedBuilder) Without(key string) *AlertLockerProcessedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertLockerProcessedBuilder) Event() (*alerts.LockerProcessed, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.LockerProcessed), nil
+}
+
+func (b *AlertLockerProcessedBuilder) WithAlertID(v int) *AlertLockerProcessedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithCheckoutID(v string) *AlertLockerProcessedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithCheckoutRecovery(v int) *AlertLockerProcessedBuilder {
+	b.setInt("checkout_recovery", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithCoupon(v string) *AlertLockerProcessedBuilder {
+	b.set("coupon", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithDownload(v string) *AlertLockerProcessedBuilder {
+	b.set("download", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithEmail(v string) *AlertLockerProcessedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithEventTime(v time.Time) *AlertLockerProcessedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithInstructions(v string) *AlertLockerProcessedBuilder {
+	b.set("instructions", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithLicense(v string) *AlertLockerProcessedBuilder {
+	b.set("license", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithMarketingConsent(v bool) *AlertLockerProcessedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithOrderID(v int) *AlertLockerProcessedBuilder {
+	b.setInt("order_id", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithProductID(v int) *AlertLockerProcessedBuilder {
+	b.setInt("product_id", v)
+	return b
+}
+
+func (b *AlertLockerProcessedBuilder) WithQuantity(v int) *AlertLockerProcessedBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+// AlertNewAudienceMemberBuilder builds alerts.NewAudienceMember alerts.
+type AlertNewAudienceMemberBuilder struct {
+	builder
+}
+
+// AlertNewAudienceMember starts building alerts.NewAudienceMember with realistic default values.
+func AlertNewAudienceMember() *AlertNewAudienceMemberBuilder {
+	return &AlertNewAudienceMemberBuilder{newBuilder(alerts.NewAudienceMemberAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertNewAudienceMemberBuilder) Set(key, value string) *AlertNewAudienceMemberBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertNewAudienceMemberBuilder) Without(key string) *AlertNewAudienceMemberBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertNewAudienceMemberBuilder) Event() (*alerts.NewAudienceMember, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.NewAudienceMember), nil
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithAlertID(v int) *AlertNewAudienceMemberBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithCreatedAt(v time.Time) *AlertNewAudienceMemberBuilder {
+	b.setDatetime("created_at", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithEmail(v string) *AlertNewAudienceMemberBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithEventTime(v time.Time) *AlertNewAudienceMemberBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithMarketingConsent(v bool) *AlertNewAudienceMemberBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithProducts(v ...int64) *AlertNewAudienceMemberBuilder {
+	b.setProducts("products", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithSource(v string) *AlertNewAudienceMemberBuilder {
+	b.set("source", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithSubscribed(v int) *AlertNewAudienceMemberBuilder {
+	b.setInt("subscribed", v)
+	return b
+}
+
+func (b *AlertNewAudienceMemberBuilder) WithUserID(v int) *AlertNewAudienceMemberBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// AlertPaymentDisputeClosedBuilder builds alerts.PaymentDisputeClosed alerts.
+type AlertPaymentDisputeClosedBuilder struct {
+	builder
+}
+
+// AlertPaymentDisputeClosed starts building alerts.PaymentDisputeClosed with realistic default values.
+func AlertPaymentDisputeClosed() *AlertPaymentDisputeClosedBuilder {
+	return &AlertPaymentDisputeClosedBuilder{newBuilder(alerts.PaymentDisputeClosedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertPaymentDisputeClosedBuilder) Set(key, value string) *AlertPaymentDisputeClosedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertPaymentDisputeClosedBuilder) Without(key string) *AlertPaymentDisputeClosedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertPaymentDisputeClosedBuilder) Event() (*alerts.PaymentDisputeClosed, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.PaymentDisputeClosed), nil
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithAlertID(v int) *AlertPaymentDisputeClosedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithAmount(v decimal.Decimal) *AlertPaymentDisputeClosedBuilder {
+	b.set("amount", v.String())
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithCheckoutID(v string) *AlertPaymentDisputeClosedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithCurrency(v string) *AlertPaymentDisputeClosedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithEmail(v string) *AlertPaymentDisputeClosedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithEventTime(v time.Time) *AlertPaymentDisputeClosedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithFeeUsd(v decimal.Decimal) *AlertPaymentDisputeClosedBuilder {
+	b.set("fee_usd", v.String())
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithMarketingConsent(v bool) *AlertPaymentDisputeClosedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithOrderID(v int) *AlertPaymentDisputeClosedBuilder {
+	b.setInt("order_id", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithPassthrough(v string) *AlertPaymentDisputeClosedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeClosedBuilder) WithStatus(v string) *AlertPaymentDisputeClosedBuilder {
+	b.set("status", v)
+	return b
+}
+
+// AlertPaymentDisputeCreatedBuilder builds alerts.PaymentDisputeCreated alerts.
+type AlertPaymentDisputeCreatedBuilder struct {
+	builder
+}
+
+// AlertPaymentDisputeCreated starts building alerts.PaymentDisputeCreated with realistic default values.
+func AlertPaymentDisputeCreated() *AlertPaymentDisputeCreatedBuilder {
+	return &AlertPaymentDisputeCreatedBuilder{newBuilder(alerts.PaymentDisputeCreatedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertPaymentDisputeCreatedBuilder) Set(key, value string) *AlertPaymentDisputeCreatedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertPaymentDisputeCreatedBuilder) Without(key string) *AlertPaymentDisputeCreatedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertPaymentDisputeCreatedBuilder) Event() (*alerts.PaymentDisputeCreated, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.PaymentDisputeCreated), nil
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithAlertID(v int) *AlertPaymentDisputeCreatedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithAmount(v decimal.Decimal) *AlertPaymentDisputeCreatedBuilder {
+	b.set("amount", v.String())
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithCheckoutID(v string) *AlertPaymentDisputeCreatedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithCurrency(v string) *AlertPaymentDisputeCreatedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithEmail(v string) *AlertPaymentDisputeCreatedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithEventTime(v time.Time) *AlertPaymentDisputeCreatedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithFeeUsd(v decimal.Decimal) *AlertPaymentDisputeCreatedBuilder {
+	b.set("fee_usd", v.String())
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithMarketingConsent(v bool) *AlertPaymentDisputeCreatedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithOrderID(v int) *AlertPaymentDisputeCreatedBuilder {
+	b.setInt("order_id", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithPassthrough(v string) *AlertPaymentDisputeCreatedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *AlertPaymentDisputeCreatedBuilder) WithStatus(v string) *AlertPaymentDisputeCreatedBuilder {
+	b.set("status", v)
+	return b
+}
+
+// AlertPaymentRefundedBuilder builds alerts.PaymentRefunded alerts.
+type AlertPaymentRefundedBuilder struct {
+	builder
+}
+
+// AlertPaymentRefunded starts building alerts.PaymentRefunded with realistic default values.
+func AlertPaymentRefunded() *AlertPaymentRefundedBuilder {
+	return &AlertPaymentRefundedBuilder{newBuilder(alerts.PaymentRefundedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertPaymentRefundedBuilder) Set(key, value string) *AlertPaymentRefundedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertPaymentRefundedBuilder) Without(key string) *AlertPaymentRefundedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertPaymentRefundedBuilder) Event() (*alerts.PaymentRefunded, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.PaymentRefunded), nil
+}
+
+func (b *AlertPaymentRefundedBuilder) WithAlertID(v int) *AlertPaymentRefundedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithAmount(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("amount", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithBalanceCurrency(v string) *AlertPaymentRefundedBuilder {
+	b.set("balance_currency", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithBalanceEarningsDecrease(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("balance_earnings_decrease", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithBalanceFeeRefund(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("balance_fee_refund", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithBalanceGrossRefund(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("balance_gross_refund", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithBalanceTaxRefund(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("balance_tax_refund", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithCheckoutID(v string) *AlertPaymentRefundedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithCurrency(v string) *AlertPaymentRefundedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithEarningsDecrease(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("earnings_decrease", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithEmail(v string) *AlertPaymentRefundedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithEventTime(v time.Time) *AlertPaymentRefundedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithFeeRefund(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("fee_refund", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithGrossRefund(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("gross_refund", v.String())
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithMarketingConsent(v bool) *AlertPaymentRefundedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithOrderID(v string) *AlertPaymentRefundedBuilder {
+	b.set("order_id", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithPassthrough(v string) *AlertPaymentRefundedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithQuantity(v int) *AlertPaymentRefundedBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithRefundType(v string) *AlertPaymentRefundedBuilder {
+	b.set("refund_type", v)
+	return b
+}
+
+func (b *AlertPaymentRefundedBuilder) WithTaxRefund(v decimal.Decimal) *AlertPaymentRefundedBuilder {
+	b.set("tax_refund", v.String())
+	return b
+}
+
+// AlertPaymentSucceededBuilder builds alerts.PaymentSucceeded alerts.
+type AlertPaymentSucceededBuilder struct {
+	builder
+}
+
+// AlertPaymentSucceeded starts building alerts.PaymentSucceeded with realistic default values.
+func AlertPaymentSucceeded() *AlertPaymentSucceededBuilder {
+	return &AlertPaymentSucceededBuilder{newBuilder(alerts.PaymentSucceededAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertPaymentSucceededBuilder) Set(key, value string) *AlertPaymentSucceededBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertPaymentSucceededBuilder) Without(key string) *AlertPaymentSucceededBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertPaymentSucceededBuilder) Event() (*alerts.PaymentSucceeded, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.PaymentSucceeded), nil
+}
+
+func (b *AlertPaymentSucceededBuilder) WithAlertID(v int) *AlertPaymentSucceededBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithBalanceCurrency(v string) *AlertPaymentSucceededBuilder {
+	b.set("balance_currency", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithBalanceEarnings(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("balance_earnings", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithBalanceFee(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("balance_fee", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithBalanceGross(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("balance_gross", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithBalanceTax(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("balance_tax", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithCheckoutID(v string) *AlertPaymentSucceededBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithCountry(v string) *AlertPaymentSucceededBuilder {
+	b.set("country", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithCoupon(v string) *AlertPaymentSucceededBuilder {
+	b.set("coupon", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithCurrency(v string) *AlertPaymentSucceededBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithCustomerName(v string) *AlertPaymentSucceededBuilder {
+	b.set("customer_name", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithEarnings(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("earnings", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithEmail(v string) *AlertPaymentSucceededBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithEventTime(v time.Time) *AlertPaymentSucceededBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithFee(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("fee", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithIP(v net.IP) *AlertPaymentSucceededBuilder {
+	b.set("ip", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithMarketingConsent(v bool) *AlertPaymentSucceededBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithOrderID(v string) *AlertPaymentSucceededBuilder {
+	b.set("order_id", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithPassthrough(v string) *AlertPaymentSucceededBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithPaymentMethod(v string) *AlertPaymentSucceededBuilder {
+	b.set("payment_method", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithPaymentTax(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("payment_tax", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithProductID(v int) *AlertPaymentSucceededBuilder {
+	b.setInt("product_id", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithProductName(v string) *AlertPaymentSucceededBuilder {
+	b.set("product_name", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithQuantity(v int) *AlertPaymentSucceededBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithReceiptURL(v string) *AlertPaymentSucceededBuilder {
+	b.set("receipt_url", v)
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithSaleGross(v decimal.Decimal) *AlertPaymentSucceededBuilder {
+	b.set("sale_gross", v.String())
+	return b
+}
+
+func (b *AlertPaymentSucceededBuilder) WithUsedPriceOverride(v bool) *AlertPaymentSucceededBuilder {
+	b.setBool("used_price_override", v)
+	return b
+}
+
+// AlertTransferCreatedBuilder builds alerts.TransferCreated alerts.
+type AlertTransferCreatedBuilder struct {
+	builder
+}
+
+// AlertTransferCreated starts building alerts.TransferCreated with realistic default values.
+func AlertTransferCreated() *AlertTransferCreatedBuilder {
+	return &AlertTransferCreatedBuilder{newBuilder(alerts.TransferCreatedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertTransferCreatedBuilder) Set(key, value string) *AlertTransferCreatedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertTransferCreatedBuilder) Without(key string) *AlertTransferCreatedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertTransferCreatedBuilder) Event() (*alerts.TransferCreated, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.TransferCreated), nil
+}
+
+func (b *AlertTransferCreatedBuilder) WithAlertID(v int) *AlertTransferCreatedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertTransferCreatedBuilder) WithAmount(v decimal.Decimal) *AlertTransferCreatedBuilder {
+	b.set("amount", v.String())
+	return b
+}
+
+func (b *AlertTransferCreatedBuilder) WithCurrency(v string) *AlertTransferCreatedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *AlertTransferCreatedBuilder) WithEventTime(v time.Time) *AlertTransferCreatedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertTransferCreatedBuilder) WithPayoutID(v int) *AlertTransferCreatedBuilder {
+	b.setInt("payout_id", v)
+	return b
+}
+
+func (b *AlertTransferCreatedBuilder) WithStatus(v string) *AlertTransferCreatedBuilder {
+	b.set("status", v)
+	return b
+}
+
+// AlertTransferPaidBuilder builds alerts.TransferPaid alerts.
+type AlertTransferPaidBuilder struct {
+	builder
+}
+
+// AlertTransferPaid starts building alerts.TransferPaid with realistic default values.
+func AlertTransferPaid() *AlertTransferPaidBuilder {
+	return &AlertTransferPaidBuilder{newBuilder(alerts.TransferPaidAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertTransferPaidBuilder) Set(key, value string) *AlertTransferPaidBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertTransferPaidBuilder) Without(key string) *AlertTransferPaidBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertTransferPaidBuilder) Event() (*alerts.TransferPaid, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.TransferPaid), nil
+}
+
+func (b *AlertTransferPaidBuilder) WithAlertID(v int) *AlertTransferPaidBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertTransferPaidBuilder) WithAmount(v decimal.Decimal) *AlertTransferPaidBuilder {
+	b.set("amount", v.String())
+	return b
+}
+
+func (b *AlertTransferPaidBuilder) WithCurrency(v string) *AlertTransferPaidBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *AlertTransferPaidBuilder) WithEventTime(v time.Time) *AlertTransferPaidBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertTransferPaidBuilder) WithPayoutID(v int) *AlertTransferPaidBuilder {
+	b.setInt("payout_id", v)
+	return b
+}
+
+func (b *AlertTransferPaidBuilder) WithStatus(v string) *AlertTransferPaidBuilder {
+	b.set("status", v)
+	return b
+}
+
+// AlertUpdateAudienceMemberBuilder builds alerts.UpdateAudienceMember alerts.
+type AlertUpdateAudienceMemberBuilder struct {
+	builder
+}
+
+// AlertUpdateAudienceMember starts building alerts.UpdateAudienceMember with realistic default values.
+func AlertUpdateAudienceMember() *AlertUpdateAudienceMemberBuilder {
+	return &AlertUpdateAudienceMemberBuilder{newBuilder(alerts.UpdateAudienceMemberAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *AlertUpdateAudienceMemberBuilder) Set(key, value string) *AlertUpdateAudienceMemberBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *AlertUpdateAudienceMemberBuilder) Without(key string) *AlertUpdateAudienceMemberBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *AlertUpdateAudienceMemberBuilder) Event() (*alerts.UpdateAudienceMember, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*alerts.UpdateAudienceMember), nil
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithAlertID(v int) *AlertUpdateAudienceMemberBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithEventTime(v time.Time) *AlertUpdateAudienceMemberBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithNewCustomerEmail(v string) *AlertUpdateAudienceMemberBuilder {
+	b.set("new_customer_email", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithNewMarketingConsent(v bool) *AlertUpdateAudienceMemberBuilder {
+	b.setPhpBool("new_marketing_consent", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithOldCustomerEmail(v string) *AlertUpdateAudienceMemberBuilder {
+	b.set("old_customer_email", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithOldMarketingConsent(v bool) *AlertUpdateAudienceMemberBuilder {
+	b.setPhpBool("old_marketing_consent", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithProducts(v ...int64) *AlertUpdateAudienceMemberBuilder {
+	b.setProducts("products", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithSource(v string) *AlertUpdateAudienceMemberBuilder {
+	b.set("source", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithUpdatedAt(v time.Time) *AlertUpdateAudienceMemberBuilder {
+	b.setDatetime("updated_at", v)
+	return b
+}
+
+func (b *AlertUpdateAudienceMemberBuilder) WithUserID(v int) *AlertUpdateAudienceMemberBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// SubscriptionCancelledBuilder builds subscription.Cancelled alerts.
+type SubscriptionCancelledBuilder struct {
+	builder
+}
+
+// SubscriptionCancelled starts building subscription.Cancelled with realistic default values.
+func SubscriptionCancelled() *SubscriptionCancelledBuilder {
+	return &SubscriptionCancelledBuilder{newBuilder(subscription.CancelledAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *SubscriptionCancelledBuilder) Set(key, value string) *SubscriptionCancelledBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *SubscriptionCancelledBuilder) Without(key string) *SubscriptionCancelledBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *SubscriptionCancelledBuilder) Event() (*subscription.Cancelled, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*subscription.Cancelled), nil
+}
+
+func (b *SubscriptionCancelledBuilder) WithAlertID(v int) *SubscriptionCancelledBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithCancellationEffectiveDate(v time.Time) *SubscriptionCancelledBuilder {
+	b.setDate("cancellation_effective_date", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithCheckoutID(v string) *SubscriptionCancelledBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithCurrency(v string) *SubscriptionCancelledBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithCustomData(v string) *SubscriptionCancelledBuilder {
+	b.set("custom_data", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithEmail(v string) *SubscriptionCancelledBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithEventTime(v time.Time) *SubscriptionCancelledBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithLinkedSubscriptions(v string) *SubscriptionCancelledBuilder {
+	b.set("linked_subscriptions", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithMarketingConsent(v bool) *SubscriptionCancelledBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithPassthrough(v string) *SubscriptionCancelledBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithQuantity(v int) *SubscriptionCancelledBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithStatus(v string) *SubscriptionCancelledBuilder {
+	b.set("status", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithSubscriptionID(v int) *SubscriptionCancelledBuilder {
+	b.setInt("subscription_id", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithSubscriptionPlanID(v int) *SubscriptionCancelledBuilder {
+	b.setInt("subscription_plan_id", v)
+	return b
+}
+
+// WithPlan is a shorthand for WithSubscriptionPlanID.
+func (b *SubscriptionCancelledBuilder) WithPlan(id int) *SubscriptionCancelledBuilder {
+	return b.WithSubscriptionPlanID(id)
+}
+
+func (b *SubscriptionCancelledBuilder) WithUnitPrice(v string) *SubscriptionCancelledBuilder {
+	b.set("unit_price", v)
+	return b
+}
+
+func (b *SubscriptionCancelledBuilder) WithUserID(v int) *SubscriptionCancelledBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// SubscriptionCreatedBuilder builds subscription.Created alerts.
+type SubscriptionCreatedBuilder struct {
+	builder
+}
+
+// SubscriptionCreated starts building subscription.Created with realistic default values.
+func SubscriptionCreated() *SubscriptionCreatedBuilder {
+	return &SubscriptionCreatedBuilder{newBuilder(subscription.CreatedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *SubscriptionCreatedBuilder) Set(key, value string) *SubscriptionCreatedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *SubscriptionCreatedBuilder) Without(key string) *SubscriptionCreatedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *SubscriptionCreatedBuilder) Event() (*subscription.Created, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*subscription.Created), nil
+}
+
+func (b *SubscriptionCreatedBuilder) WithAlertID(v int) *SubscriptionCreatedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithCancelURL(v string) *SubscriptionCreatedBuilder {
+	b.set("cancel_url", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithCheckoutID(v string) *SubscriptionCreatedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithCurrency(v string) *SubscriptionCreatedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithCustomData(v string) *SubscriptionCreatedBuilder {
+	b.set("custom_data", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithEmail(v string) *SubscriptionCreatedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithEventTime(v time.Time) *SubscriptionCreatedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithLinkedSubscriptions(v string) *SubscriptionCreatedBuilder {
+	b.set("linked_subscriptions", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithMarketingConsent(v bool) *SubscriptionCreatedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithNextBillDate(v time.Time) *SubscriptionCreatedBuilder {
+	b.setDate("next_bill_date", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithPassthrough(v string) *SubscriptionCreatedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithQuantity(v int) *SubscriptionCreatedBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithSourcePage(v string) *SubscriptionCreatedBuilder {
+	b.set("source_page", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithSource(v string) *SubscriptionCreatedBuilder {
+	b.set("source", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithStatus(v string) *SubscriptionCreatedBuilder {
+	b.set("status", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithSubscriptionID(v int) *SubscriptionCreatedBuilder {
+	b.setInt("subscription_id", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithSubscriptionPlanID(v int) *SubscriptionCreatedBuilder {
+	b.setInt("subscription_plan_id", v)
+	return b
+}
+
+// WithPlan is a shorthand for WithSubscriptionPlanID.
+func (b *SubscriptionCreatedBuilder) WithPlan(id int) *SubscriptionCreatedBuilder {
+	return b.WithSubscriptionPlanID(id)
+}
+
+func (b *SubscriptionCreatedBuilder) WithUnitPrice(v string) *SubscriptionCreatedBuilder {
+	b.set("unit_price", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithUpdateURL(v string) *SubscriptionCreatedBuilder {
+	b.set("update_url", v)
+	return b
+}
+
+func (b *SubscriptionCreatedBuilder) WithUserID(v int) *SubscriptionCreatedBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// SubscriptionPaymentFailedBuilder builds subscription.PaymentFailed alerts.
+type SubscriptionPaymentFailedBuilder struct {
+	builder
+}
+
+// SubscriptionPaymentFailed starts building subscription.PaymentFailed with realistic default values.
+func SubscriptionPaymentFailed() *SubscriptionPaymentFailedBuilder {
+	return &SubscriptionPaymentFailedBuilder{newBuilder(subscription.PaymentFailedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *SubscriptionPaymentFailedBuilder) Set(key, value string) *SubscriptionPaymentFailedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *SubscriptionPaymentFailedBuilder) Without(key string) *SubscriptionPaymentFailedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *SubscriptionPaymentFailedBuilder) Event() (*subscription.PaymentFailed, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*subscription.PaymentFailed), nil
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithAlertID(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithAmount(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("amount", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithAttemptNumber(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("attempt_number", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithCancelURL(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("cancel_url", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithCheckoutID(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithCurrency(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithCustomData(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("custom_data", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithEmail(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithEventTime(v time.Time) *SubscriptionPaymentFailedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithHardFailure(v bool) *SubscriptionPaymentFailedBuilder {
+	b.setPhpBool("hard_failure", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithInstalments(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("instalments", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithMarketingConsent(v bool) *SubscriptionPaymentFailedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithNextRetryDate(v time.Time) *SubscriptionPaymentFailedBuilder {
+	b.setDate("next_retry_date", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithOrderID(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("order_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithPassthrough(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithQuantity(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithStatus(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("status", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithSubscriptionID(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("subscription_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithSubscriptionPaymentID(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("subscription_payment_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithSubscriptionPlanID(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("subscription_plan_id", v)
+	return b
+}
+
+// WithPlan is a shorthand for WithSubscriptionPlanID.
+func (b *SubscriptionPaymentFailedBuilder) WithPlan(id int) *SubscriptionPaymentFailedBuilder {
+	return b.WithSubscriptionPlanID(id)
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithUnitPrice(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("unit_price", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithUpdateURL(v string) *SubscriptionPaymentFailedBuilder {
+	b.set("update_url", v)
+	return b
+}
+
+func (b *SubscriptionPaymentFailedBuilder) WithUserID(v int) *SubscriptionPaymentFailedBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// SubscriptionPaymentRefundedBuilder builds subscription.PaymentRefunded alerts.
+type SubscriptionPaymentRefundedBuilder struct {
+	builder
+}
+
+// SubscriptionPaymentRefunded starts building subscription.PaymentRefunded with realistic default values.
+func SubscriptionPaymentRefunded() *SubscriptionPaymentRefundedBuilder {
+	return &SubscriptionPaymentRefundedBuilder{newBuilder(subscription.PaymentRefundedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *SubscriptionPaymentRefundedBuilder) Set(key, value string) *SubscriptionPaymentRefundedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *SubscriptionPaymentRefundedBuilder) Without(key string) *SubscriptionPaymentRefundedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *SubscriptionPaymentRefundedBuilder) Event() (*subscription.PaymentRefunded, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*subscription.PaymentRefunded), nil
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithAlertID(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithAmount(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("amount", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithBalanceCurrency(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("balance_currency", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithBalanceEarningsDecrease(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("balance_earnings_decrease", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithBalanceFeeRefund(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("balance_fee_refund", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithBalanceGrossRefund(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("balance_gross_refund", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithBalanceTaxRefund(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("balance_tax_refund", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithCheckoutID(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithCurrency(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithEarningsDecrease(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("earnings_decrease", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithEmail(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithEventTime(v time.Time) *SubscriptionPaymentRefundedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithFeeRefund(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("fee_refund", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithGrossRefund(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("gross_refund", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithInitialPayment(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("initial_payment", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithInstalments(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("instalments", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithMarketingConsent(v bool) *SubscriptionPaymentRefundedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithOrderID(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("order_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithPassthrough(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithQuantity(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithRefundReason(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("refund_reason", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithRefundType(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("refund_type", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithStatus(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("status", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithSubscriptionID(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("subscription_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithSubscriptionPaymentID(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("subscription_payment_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithSubscriptionPlanID(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("subscription_plan_id", v)
+	return b
+}
+
+// WithPlan is a shorthand for WithSubscriptionPlanID.
+func (b *SubscriptionPaymentRefundedBuilder) WithPlan(id int) *SubscriptionPaymentRefundedBuilder {
+	return b.WithSubscriptionPlanID(id)
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithTaxRefund(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("tax_refund", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithUnitPrice(v string) *SubscriptionPaymentRefundedBuilder {
+	b.set("unit_price", v)
+	return b
+}
+
+func (b *SubscriptionPaymentRefundedBuilder) WithUserID(v int) *SubscriptionPaymentRefundedBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// SubscriptionPaymentSucceededBuilder builds subscription.PaymentSucceeded alerts.
+type SubscriptionPaymentSucceededBuilder struct {
+	builder
+}
+
+// SubscriptionPaymentSucceeded starts building subscription.PaymentSucceeded with realistic default values.
+func SubscriptionPaymentSucceeded() *SubscriptionPaymentSucceededBuilder {
+	return &SubscriptionPaymentSucceededBuilder{newBuilder(subscription.PaymentSucceededAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *SubscriptionPaymentSucceededBuilder) Set(key, value string) *SubscriptionPaymentSucceededBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *SubscriptionPaymentSucceededBuilder) Without(key string) *SubscriptionPaymentSucceededBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *SubscriptionPaymentSucceededBuilder) Event() (*subscription.PaymentSucceeded, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*subscription.PaymentSucceeded), nil
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithAlertID(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithBalanceCurrency(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("balance_currency", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithBalanceEarnings(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("balance_earnings", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithBalanceFee(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("balance_fee", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithBalanceGross(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("balance_gross", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithBalanceTax(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("balance_tax", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithCheckoutID(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithCountry(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("country", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithCoupon(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("coupon", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithCurrency(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithCustomData(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("custom_data", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithCustomerName(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("customer_name", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithEarnings(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("earnings", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithEmail(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithEventTime(v time.Time) *SubscriptionPaymentSucceededBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithFee(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("fee", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithInitialPayment(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("initial_payment", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithInstalments(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("instalments", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithMarketingConsent(v bool) *SubscriptionPaymentSucceededBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithNextBillDate(v time.Time) *SubscriptionPaymentSucceededBuilder {
+	b.setDate("next_bill_date", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithNextPaymentAmount(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("next_payment_amount", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithOrderID(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("order_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithPassthrough(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithPaymentMethod(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("payment_method", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithPaymentTax(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("payment_tax", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithPlanName(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("plan_name", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithQuantity(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("quantity", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithReceiptURL(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("receipt_url", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithSaleGross(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("sale_gross", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithStatus(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("status", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithSubscriptionID(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("subscription_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithSubscriptionPaymentID(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("subscription_payment_id", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithSubscriptionPlanID(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("subscription_plan_id", v)
+	return b
+}
+
+// WithPlan is a shorthand for WithSubscriptionPlanID.
+func (b *SubscriptionPaymentSucceededBuilder) WithPlan(id int) *SubscriptionPaymentSucceededBuilder {
+	return b.WithSubscriptionPlanID(id)
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithUnitPrice(v string) *SubscriptionPaymentSucceededBuilder {
+	b.set("unit_price", v)
+	return b
+}
+
+func (b *SubscriptionPaymentSucceededBuilder) WithUserID(v int) *SubscriptionPaymentSucceededBuilder {
+	b.setInt("user_id", v)
+	return b
+}
+
+// SubscriptionUpdatedBuilder builds subscription.Updated alerts.
+type SubscriptionUpdatedBuilder struct {
+	builder
+}
+
+// SubscriptionUpdated starts building subscription.Updated with realistic default values.
+func SubscriptionUpdated() *SubscriptionUpdatedBuilder {
+	return &SubscriptionUpdatedBuilder{newBuilder(subscription.UpdatedAlertName)}
+}
+
+// Set sets field key to value exactly as paddle would send it.
+func (b *SubscriptionUpdatedBuilder) Set(key, value string) *SubscriptionUpdatedBuilder {
+	b.set(key, value)
+	return b
+}
+
+// Without removes field key.
+func (b *SubscriptionUpdatedBuilder) Without(key string) *SubscriptionUpdatedBuilder {
+	delete(b.fields, key)
+	return b
+}
+
+// Event returns alert decoded the same way router decodes it.
+func (b *SubscriptionUpdatedBuilder) Event() (*subscription.Updated, error) {
+	e, err := b.event()
+	if err != nil {
+		return nil, err
+	}
+	return e.(*subscription.Updated), nil
+}
+
+func (b *SubscriptionUpdatedBuilder) WithAlertID(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("alert_id", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithCancelURL(v string) *SubscriptionUpdatedBuilder {
+	b.set("cancel_url", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithCheckoutID(v string) *SubscriptionUpdatedBuilder {
+	b.set("checkout_id", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithCurrency(v string) *SubscriptionUpdatedBuilder {
+	b.set("currency", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithCustomData(v string) *SubscriptionUpdatedBuilder {
+	b.set("custom_data", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithEmail(v string) *SubscriptionUpdatedBuilder {
+	b.set("email", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithEventTime(v time.Time) *SubscriptionUpdatedBuilder {
+	b.setDatetime("event_time", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithLinkedSubscriptions(v string) *SubscriptionUpdatedBuilder {
+	b.set("linked_subscriptions", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithMarketingConsent(v bool) *SubscriptionUpdatedBuilder {
+	b.setPhpBool("marketing_consent", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithNewPrice(v string) *SubscriptionUpdatedBuilder {
+	b.set("new_price", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithNewQuantity(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("new_quantity", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithNewUnitPrice(v string) *SubscriptionUpdatedBuilder {
+	b.set("new_unit_price", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithNextBillDate(v time.Time) *SubscriptionUpdatedBuilder {
+	b.setDate("next_bill_date", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithOldNextBillDate(v time.Time) *SubscriptionUpdatedBuilder {
+	b.setDate("old_next_bill_date", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithOldPrice(v string) *SubscriptionUpdatedBuilder {
+	b.set("old_price", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithOldQuantity(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("old_quantity", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithOldStatus(v string) *SubscriptionUpdatedBuilder {
+	b.set("old_status", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithOldSubscriptionPlanID(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("old_subscription_plan_id", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithOldUnitPrice(v string) *SubscriptionUpdatedBuilder {
+	b.set("old_unit_price", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithPassthrough(v string) *SubscriptionUpdatedBuilder {
+	b.set("passthrough", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithStatus(v string) *SubscriptionUpdatedBuilder {
+	b.set("status", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithSubscriptionID(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("subscription_id", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithSubscriptionPlanID(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("subscription_plan_id", v)
+	return b
+}
+
+// WithPlan is a shorthand for WithSubscriptionPlanID.
+func (b *SubscriptionUpdatedBuilder) WithPlan(id int) *SubscriptionUpdatedBuilder {
+	return b.WithSubscriptionPlanID(id)
+}
+
+func (b *SubscriptionUpdatedBuilder) WithUpdateURL(v string) *SubscriptionUpdatedBuilder {
+	b.set("update_url", v)
+	return b
+}
+
+func (b *SubscriptionUpdatedBuilder) WithUserID(v int) *SubscriptionUpdatedBuilder {
+	b.setInt("user_id", v)
+	return b
+}
@@ -0,0 +1,96 @@
+package paddletest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/alerts"
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/router"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requester interface {
+	Request() *http.Request
+}
+
+func TestBuilders(t *testing.T) {
+	builders := map[string]requester{
+		alerts.HighRiskTransactionCreatedAlertName: AlertHighRiskTransactionCreated(),
+		alerts.HighRiskTransactionUpdatedAlertName: AlertHighRiskTransactionUpdated(),
+		alerts.LockerProcessedAlertName:            AlertLockerProcessed(),
+		alerts.NewAudienceMemberAlertName:          AlertNewAudienceMember().WithProducts(1, 2, 3),
+		alerts.PaymentDisputeClosedAlertName:       AlertPaymentDisputeClosed(),
+		alerts.PaymentDisputeCreatedAlertName:      AlertPaymentDisputeCreated(),
+		alerts.PaymentRefundedAlertName:            AlertPaymentRefunded(),
+		alerts.PaymentSucceededAlertName:           AlertPaymentSucceeded().WithIP(net.ParseIP("10.0.0.1")).WithUsedPriceOverride(true),
+		alerts.TransferCreatedAlertName:            AlertTransferCreated(),
+		alerts.TransferPaidAlertName:               AlertTransferPaid().WithAmount(decimal.RequireFromString("10.50")),
+		alerts.UpdateAudienceMemberAlertName:       AlertUpdateAudienceMember().WithOldMarketingConsent(true),
+		subscription.CancelledAlertName:            SubscriptionCancelled().WithCancellationEffectiveDate(time.Now()),
+		subscription.CreatedAlertName:              SubscriptionCreated().WithPlan(123),
+		subscription.PaymentFailedAlertName:        SubscriptionPaymentFailed().WithHardFailure(false),
+		subscription.PaymentRefundedAlertName:      SubscriptionPaymentRefunded(),
+		subscription.PaymentSucceededAlertName:     SubscriptionPaymentSucceeded().WithEventTime(time.Now()),
+		subscription.UpdatedAlertName:              SubscriptionUpdated().WithNewQuantity(3),
+	}
+	assert.Len(t, builders, 17)
+	var got events.Event
+	handler := router.NewRouter(router.Config{
+		Verifier:  Verifier(),
+		Unhandled: router.UnhandledCatchAll,
+		CatchAll: router.EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+			got = e
+		}),
+	}).Handler()
+	for name, b := range builders {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			got = nil
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, b.Request())
+			assert.Equal(http.StatusOK, rw.Code, rw.Body.String())
+			if assert.NotNil(got) {
+				assert.Equal(name, got.GetAlertName())
+			}
+		})
+	}
+}
+
+func TestSubscriptionCreated(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	var got *subscription.Created
+	handler := router.NewRouter(router.Config{
+		Verifier: Verifier(),
+		SubscriptionCreated: router.SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+			got = e
+		}),
+	}).Handler()
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, SubscriptionCreated().WithPlan(123).WithEmail("a@example.org").Request())
+	assert.Equal(http.StatusOK, rw.Code)
+	require.NotNil(got)
+	assert.Equal(123, got.SubscriptionPlanID)
+	assert.Equal("a@example.org", got.Email)
+
+	e, err := SubscriptionCreated().WithPlan(123).WithEmail("a@example.org").Event()
+	require.NoError(err)
+	assert.Equal(got, e)
+}
+
+func TestDeterministic(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(AlertTransferPaid().Body(), AlertTransferPaid().Body())
+	assert.Contains(string(PublicKeyPEM()), "-----BEGIN PUBLIC KEY-----")
+	f := AlertTransferPaid().Without("currency").Set("status", "unpaid").Fields()
+	assert.NotContains(f, "currency")
+	assert.Equal("unpaid", f["status"])
+	assert.NoError(Verifier().Verify(f))
+}
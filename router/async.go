@@ -0,0 +1,119 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/internal/response"
+)
+
+// detachedContext keeps values of request context, but is never
+// cancelled, so handlers running after response was sent can use it.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+type job struct {
+	ev       events.Event
+	req      *http.Request
+	dispatch func(events.Event, http.ResponseWriter, *http.Request)
+}
+
+// queue is a bounded queue of events processed by fixed number of workers.
+type queue struct {
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan job
+	wg     sync.WaitGroup
+}
+
+func newQueue(workers, size int) *queue {
+	if size <= 0 {
+		size = workers
+	}
+	q := &queue{jobs: make(chan job, size)}
+	q.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+func (q *queue) work() {
+	defer q.wg.Done()
+	for j := range q.jobs {
+		q.run(j)
+	}
+}
+
+func (q *queue) run(j job) {
+	ctx := j.req.Context()
+	defer func() {
+		if err := recover(); err != nil {
+			logf(ctx, "paddle: %s handler panicked: %v", j.ev.GetAlertName(), err)
+		}
+	}()
+	rec := response.NewRecorder()
+	j.dispatch(j.ev, rec, j.req)
+	if status := rec.Status(); status >= http.StatusBadRequest {
+		logf(ctx, "paddle: %s handler responded with %d after alert was acknowledged", j.ev.GetAlertName(), status)
+	}
+}
+
+// enqueue acknowledges event if it was queued, responds with
+// 503 Service Unavailable if queue is full or shut down.
+func (q *queue) enqueue(ev events.Event, rw http.ResponseWriter, req *http.Request, dispatch func(events.Event, http.ResponseWriter, *http.Request)) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if !q.closed {
+		select {
+		case q.jobs <- job{ev, req.WithContext(detachedContext{req.Context()}), dispatch}:
+			rw.WriteHeader(http.StatusOK)
+			return
+		default:
+		}
+	}
+	err := errQueueFull
+	if q.closed {
+		err = errQueueClosed
+	}
+	writeError(rw, req, http.StatusServiceUnavailable, err)
+}
+
+func (q *queue) shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops accepting events and waits until queued events are
+// processed or ctx is done. Events arriving after Shutdown was called
+// are answered with 503 Service Unavailable. It is a no-op if router
+// does not process events asynchronously.
+func (r Router) Shutdown(ctx context.Context) error {
+	if r.queue == nil {
+		return nil
+	}
+	return r.queue.shutdown(ctx)
+}
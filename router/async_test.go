@@ -0,0 +1,139 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterAsync(t *testing.T) {
+	newReq := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created")))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+
+	t.Run("AcknowledgesBeforeHandler", func(t *testing.T) {
+		assert := assert.New(t)
+		release := make(chan struct{})
+		done := make(chan error, 1)
+		r := NewRouter(Config{
+			Workers: 1,
+			SubscriptionCreated: SubscriptionCreatedContextFunc(func(ctx context.Context, e *subscription.Created) error {
+				<-release
+				done <- ctx.Err()
+				return nil
+			}),
+		})
+		req := newReq()
+		ctx, cancel := context.WithCancel(req.Context())
+		rw := httptest.NewRecorder()
+		r.Handler().ServeHTTP(rw, req.WithContext(ctx))
+		cancel()
+		assert.Equal(http.StatusOK, rw.Code)
+		close(release)
+		assert.NoError(<-done, "handler context must not be cancelled with request")
+		assert.NoError(r.Shutdown(context.Background()))
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		assert := assert.New(t)
+		release := make(chan struct{})
+		started := make(chan struct{}, 1)
+		r := NewRouter(Config{
+			Workers:   1,
+			QueueSize: 1,
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+				started <- struct{}{}
+				<-release
+			}),
+		})
+		handler := r.Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq())
+		assert.Equal(http.StatusOK, rw.Code)
+		<-started
+		rw = httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq())
+		assert.Equal(http.StatusOK, rw.Code)
+		rw = httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq())
+		assert.Equal(http.StatusServiceUnavailable, rw.Code)
+		close(release)
+		assert.NoError(r.Shutdown(context.Background()))
+	})
+
+	t.Run("ShutdownDrains", func(t *testing.T) {
+		assert := assert.New(t)
+		var mu sync.Mutex
+		var handled int
+		r := NewRouter(Config{
+			Workers:   2,
+			QueueSize: 10,
+			ErrorLog:  log.New(ioutil.Discard, "", 0),
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				handled++
+				mu.Unlock()
+			}),
+		})
+		handler := r.Handler()
+		for i := 0; i < 10; i++ {
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newReq())
+			assert.Equal(http.StatusOK, rw.Code)
+		}
+		assert.NoError(r.Shutdown(context.Background()))
+		assert.Equal(10, handled)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq())
+		assert.Equal(http.StatusServiceUnavailable, rw.Code)
+	})
+
+	t.Run("ShutdownTimeout", func(t *testing.T) {
+		assert := assert.New(t)
+		release := make(chan struct{})
+		r := NewRouter(Config{
+			Workers: 1,
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+				<-release
+			}),
+		})
+		r.Handler().ServeHTTP(httptest.NewRecorder(), newReq())
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Equal(context.DeadlineExceeded, r.Shutdown(ctx))
+		close(release)
+		assert.NoError(r.Shutdown(context.Background()))
+	})
+
+	t.Run("LogsFailedHandler", func(t *testing.T) {
+		assert := assert.New(t)
+		var logBuf bytes.Buffer
+		r := NewRouter(Config{
+			Workers:  1,
+			ErrorLog: log.New(&logBuf, "", 0),
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+			}),
+		})
+		r.Handler().ServeHTTP(httptest.NewRecorder(), newReq())
+		assert.NoError(r.Shutdown(context.Background()))
+		assert.Contains(logBuf.String(), "subscription_created handler responded with 500")
+	})
+
+	t.Run("ShutdownSync", func(t *testing.T) {
+		assert.NoError(t, NewRouter(Config{}).Shutdown(context.Background()))
+	})
+}
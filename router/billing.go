@@ -0,0 +1,111 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/dennor/go-paddle/events/billing"
+	"github.com/dennor/go-paddle/middleware"
+	"github.com/dennor/go-paddle/signature"
+)
+
+// BillingConfig configures BillingRouter. Handlers are selected by
+// entity of notification event type, Notification receives
+// notifications for entities without typed support.
+//
+// MaxBodyBytes limits size of notification request body, larger bodies
+// are rejected with request entity too large before their signature is
+// checked. ErrorHandler, if set, writes responses for rejected and
+// unhandled notifications, see Config.
+type BillingConfig struct {
+	Verifier     billing.Verifier
+	MaxBodyBytes int64
+	ErrorHandler middleware.ErrorHandler
+	Transaction  BillingTransaction
+	Subscription BillingSubscription
+	Customer     BillingCustomer
+	Notification BillingNotification
+}
+
+// BillingRouter routes paddle billing (v2) notifications. It is a counterpart
+// of Router for classic alerts and both can be served side by side.
+type BillingRouter struct {
+	BillingConfig
+	transaction  BillingTransaction
+	subscription BillingSubscription
+	customer     BillingCustomer
+	notification BillingNotification
+}
+
+func (r BillingRouter) Handler() http.Handler {
+	r.transaction = r.Transaction
+	if r.transaction == nil {
+		r.transaction = billingTransactionNotFound
+	}
+	r.subscription = r.Subscription
+	if r.subscription == nil {
+		r.subscription = billingSubscriptionNotFound
+	}
+	r.customer = r.Customer
+	if r.customer == nil {
+		r.customer = billingCustomerNotFound
+	}
+	r.notification = r.Notification
+	if r.notification == nil {
+		r.notification = billingNotificationNotFound
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := r.readBody(rw, req)
+		if err != nil {
+			writeRejection(r.ErrorHandler, rw, req, err)
+			return
+		}
+		if r.Verifier != nil {
+			if err := r.Verifier.Verify(body, req.Header.Get(signature.HeaderName)); err != nil {
+				writeRejection(r.ErrorHandler, rw, req, middleware.Error{Class: middleware.ErrSignature, Err: err})
+				return
+			}
+		}
+		ev, err := billing.Unmarshal(body)
+		if err != nil {
+			writeRejection(r.ErrorHandler, rw, req, middleware.Error{Class: middleware.ErrDecode, Err: err})
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if r.ErrorHandler != nil {
+			req = req.WithContext(context.WithValue(req.Context(), errorHandlerKey{}, r.ErrorHandler))
+		}
+		switch tev := ev.(type) {
+		case *billing.TransactionNotification:
+			r.transaction.ServeHTTP(tev, rw, req)
+		case *billing.SubscriptionNotification:
+			r.subscription.ServeHTTP(tev, rw, req)
+		case *billing.CustomerNotification:
+			r.customer.ServeHTTP(tev, rw, req)
+		case *billing.Notification:
+			r.notification.ServeHTTP(tev, rw, req)
+		}
+	})
+}
+
+// readBody reads request body limited to MaxBodyBytes.
+func (r BillingRouter) readBody(rw http.ResponseWriter, req *http.Request) ([]byte, error) {
+	if r.MaxBodyBytes > 0 {
+		req.Body = http.MaxBytesReader(rw, req.Body, r.MaxBodyBytes)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	switch {
+	case err == nil:
+		return body, nil
+	case r.MaxBodyBytes > 0 && int64(len(body)) >= r.MaxBodyBytes:
+		return nil, middleware.Error{Class: middleware.ErrBodyTooLarge, Err: err}
+	}
+	return nil, middleware.Error{Class: middleware.ErrBodyRead, Err: err}
+}
+
+func NewBillingRouter(c BillingConfig) BillingRouter {
+	return BillingRouter{BillingConfig: c}
+}
@@ -0,0 +1,65 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/dennor/go-paddle/events/billing"
+)
+
+type BillingTransaction interface {
+	ServeHTTP(*billing.TransactionNotification, http.ResponseWriter, *http.Request)
+}
+
+type BillingTransactionFunc func(*billing.TransactionNotification, http.ResponseWriter, *http.Request)
+
+func (f BillingTransactionFunc) ServeHTTP(e *billing.TransactionNotification, rw http.ResponseWriter, req *http.Request) {
+	f(e, rw, req)
+}
+
+type BillingSubscription interface {
+	ServeHTTP(*billing.SubscriptionNotification, http.ResponseWriter, *http.Request)
+}
+
+type BillingSubscriptionFunc func(*billing.SubscriptionNotification, http.ResponseWriter, *http.Request)
+
+func (f BillingSubscriptionFunc) ServeHTTP(e *billing.SubscriptionNotification, rw http.ResponseWriter, req *http.Request) {
+	f(e, rw, req)
+}
+
+type BillingCustomer interface {
+	ServeHTTP(*billing.CustomerNotification, http.ResponseWriter, *http.Request)
+}
+
+type BillingCustomerFunc func(*billing.CustomerNotification, http.ResponseWriter, *http.Request)
+
+func (f BillingCustomerFunc) ServeHTTP(e *billing.CustomerNotification, rw http.ResponseWriter, req *http.Request) {
+	f(e, rw, req)
+}
+
+type BillingNotification interface {
+	ServeHTTP(*billing.Notification, http.ResponseWriter, *http.Request)
+}
+
+type BillingNotificationFunc func(*billing.Notification, http.ResponseWriter, *http.Request)
+
+func (f BillingNotificationFunc) ServeHTTP(e *billing.Notification, rw http.ResponseWriter, req *http.Request) {
+	f(e, rw, req)
+}
+
+var (
+	billingTransactionNotFound = BillingTransactionFunc(func(e *billing.TransactionNotification, rw http.ResponseWriter, req *http.Request) {
+		handlerNotFound(rw, req, e.EventType)
+	})
+
+	billingSubscriptionNotFound = BillingSubscriptionFunc(func(e *billing.SubscriptionNotification, rw http.ResponseWriter, req *http.Request) {
+		handlerNotFound(rw, req, e.EventType)
+	})
+
+	billingCustomerNotFound = BillingCustomerFunc(func(e *billing.CustomerNotification, rw http.ResponseWriter, req *http.Request) {
+		handlerNotFound(rw, req, e.EventType)
+	})
+
+	billingNotificationNotFound = BillingNotificationFunc(func(e *billing.Notification, rw http.ResponseWriter, req *http.Request) {
+		handlerNotFound(rw, req, e.EventType)
+	})
+)
@@ -0,0 +1,152 @@
+package router
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/events/billing"
+	"github.com/dennor/go-paddle/middleware"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/dennor/go-paddle/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBillingRouter(t *testing.T) {
+	secret := signature.HMAC{Secret: []byte("pdl_ntfset_secret")}
+	newRequest := func(body string, header string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		req.Header.Set(signature.HeaderName, header)
+		return req
+	}
+
+	t.Run("DispatchesByEntity", func(t *testing.T) {
+		data := []struct {
+			body      string
+			eventType string
+		}{
+			{`{"event_type":"transaction.completed","data":{"id":"txn_1"}}`, billing.TransactionCompletedEventType},
+			{`{"event_type":"subscription.activated","data":{"id":"sub_1"}}`, billing.SubscriptionActivatedEventType},
+			{`{"event_type":"customer.updated","data":{"id":"ctm_1"}}`, billing.CustomerUpdatedEventType},
+			{`{"event_type":"adjustment.created","data":{"id":"adj_1"}}`, "adjustment.created"},
+		}
+		for _, tt := range data {
+			assert := assert.New(t)
+			var got string
+			record := func(e billing.Event, rw http.ResponseWriter, req *http.Request) {
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(err)
+				assert.Equal(tt.body, string(b))
+				got = e.GetEventType()
+			}
+			handler := NewBillingRouter(BillingConfig{
+				Verifier: secret,
+				Transaction: BillingTransactionFunc(func(e *billing.TransactionNotification, rw http.ResponseWriter, req *http.Request) {
+					assert.Equal("txn_1", e.Data.ID)
+					record(e, rw, req)
+				}),
+				Subscription: BillingSubscriptionFunc(func(e *billing.SubscriptionNotification, rw http.ResponseWriter, req *http.Request) {
+					assert.Equal("sub_1", e.Data.ID)
+					record(e, rw, req)
+				}),
+				Customer: BillingCustomerFunc(func(e *billing.CustomerNotification, rw http.ResponseWriter, req *http.Request) {
+					assert.Equal("ctm_1", e.Data.ID)
+					record(e, rw, req)
+				}),
+				Notification: BillingNotificationFunc(func(e *billing.Notification, rw http.ResponseWriter, req *http.Request) {
+					record(e, rw, req)
+				}),
+			}).Handler()
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newRequest(tt.body, secret.Sign([]byte(tt.body), time.Now())))
+			assert.Equal(http.StatusOK, rw.Code)
+			assert.Equal(tt.eventType, got)
+		}
+	})
+
+	t.Run("RejectsInvalidSignature", func(t *testing.T) {
+		assert := assert.New(t)
+		body := `{"event_type":"transaction.completed","data":{"id":"txn_1"}}`
+		handler := NewBillingRouter(BillingConfig{
+			Verifier: secret,
+			Transaction: BillingTransactionFunc(func(e *billing.TransactionNotification, rw http.ResponseWriter, req *http.Request) {
+				t.Fatal("handler should not be called")
+			}),
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(body, secret.Sign([]byte(`{}`), time.Now())))
+		assert.Equal(http.StatusForbidden, rw.Code)
+	})
+
+	t.Run("MissingHandler", func(t *testing.T) {
+		assert := assert.New(t)
+		body := `{"event_type":"customer.created","data":{"id":"ctm_1"}}`
+		rw := httptest.NewRecorder()
+		NewBillingRouter(BillingConfig{}).Handler().ServeHTTP(rw, newRequest(body, ""))
+		assert.Equal(http.StatusNotFound, rw.Code)
+	})
+
+	t.Run("MaxBodyBytes", func(t *testing.T) {
+		assert := assert.New(t)
+		body := `{"event_type":"transaction.completed","data":{"id":"txn_1"}}`
+		handler := NewBillingRouter(BillingConfig{
+			Verifier:     secret,
+			MaxBodyBytes: 10,
+			Transaction: BillingTransactionFunc(func(e *billing.TransactionNotification, rw http.ResponseWriter, req *http.Request) {
+				t.Fatal("handler should not be called")
+			}),
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newRequest(body, secret.Sign([]byte(body), time.Now())))
+		assert.Equal(http.StatusRequestEntityTooLarge, rw.Code)
+	})
+
+	t.Run("ErrorHandler", func(t *testing.T) {
+		handler := NewBillingRouter(BillingConfig{
+			Verifier:     secret,
+			ErrorHandler: middleware.WriteProblem,
+		}).Handler()
+		for _, tt := range []struct {
+			name, body, header, problem string
+			status                      int
+		}{
+			{
+				name:    "Rejected",
+				body:    `{"event_type":"customer.created"}`,
+				header:  secret.Sign([]byte(`{}`), time.Now()),
+				status:  http.StatusForbidden,
+				problem: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"invalid signature"}`,
+			},
+			{
+				name:    "Unhandled",
+				body:    `{"event_type":"customer.created"}`,
+				header:  secret.Sign([]byte(`{"event_type":"customer.created"}`), time.Now()),
+				status:  http.StatusNotFound,
+				problem: `{"type":"about:blank","title":"Not Found","status":404}`,
+			},
+		} {
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newRequest(tt.body, tt.header))
+			assert.Equal(t, tt.status, rw.Code, tt.name)
+			assert.Equal(t, mime.ApplicationProblemJSON, rw.Header().Get(mime.ContentTypeHeader), tt.name)
+			assert.JSONEq(t, tt.problem, rw.Body.String(), tt.name)
+		}
+	})
+
+	t.Run("SideBySideWithClassic", func(t *testing.T) {
+		assert := assert.New(t)
+		mux := http.NewServeMux()
+		mux.Handle("/paddle/classic", NewRouter(Config{}).Handler())
+		mux.Handle("/paddle/billing", NewBillingRouter(BillingConfig{
+			Customer: BillingCustomerFunc(func(e *billing.CustomerNotification, rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusNoContent)
+			}),
+		}).Handler())
+		rw := httptest.NewRecorder()
+		mux.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/paddle/billing", bytes.NewReader([]byte(`{"event_type":"customer.created"}`))))
+		assert.Equal(http.StatusNoContent, rw.Code)
+	})
+}
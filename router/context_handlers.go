@@ -0,0 +1,128 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/alerts"
+	"github.com/dennor/go-paddle/events/subscription"
+)
+
+// Context funcs are alternative handler forms which do not touch
+// http.ResponseWriter. Returned error is mapped to response status,
+// see Retryable and Permanent.
+
+type EventContextFunc func(context.Context, events.Event) error
+
+func (f EventContextFunc) ServeHTTP(e events.Event, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type RawContextFunc func(context.Context, *events.Raw) error
+
+func (f RawContextFunc) ServeHTTP(e *events.Raw, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertHighRiskTransactionCreatedContextFunc func(context.Context, *alerts.HighRiskTransactionCreated) error
+
+func (f AlertHighRiskTransactionCreatedContextFunc) ServeHTTP(e *alerts.HighRiskTransactionCreated, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertHighRiskTransactionUpdatedContextFunc func(context.Context, *alerts.HighRiskTransactionUpdated) error
+
+func (f AlertHighRiskTransactionUpdatedContextFunc) ServeHTTP(e *alerts.HighRiskTransactionUpdated, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertLockerProcessedContextFunc func(context.Context, *alerts.LockerProcessed) error
+
+func (f AlertLockerProcessedContextFunc) ServeHTTP(e *alerts.LockerProcessed, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertNewAudienceMemberContextFunc func(context.Context, *alerts.NewAudienceMember) error
+
+func (f AlertNewAudienceMemberContextFunc) ServeHTTP(e *alerts.NewAudienceMember, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertPaymentDisputeClosedContextFunc func(context.Context, *alerts.PaymentDisputeClosed) error
+
+func (f AlertPaymentDisputeClosedContextFunc) ServeHTTP(e *alerts.PaymentDisputeClosed, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertPaymentDisputeCreatedContextFunc func(context.Context, *alerts.PaymentDisputeCreated) error
+
+func (f AlertPaymentDisputeCreatedContextFunc) ServeHTTP(e *alerts.PaymentDisputeCreated, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertPaymentRefundedContextFunc func(context.Context, *alerts.PaymentRefunded) error
+
+func (f AlertPaymentRefundedContextFunc) ServeHTTP(e *alerts.PaymentRefunded, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertPaymentSucceededContextFunc func(context.Context, *alerts.PaymentSucceeded) error
+
+func (f AlertPaymentSucceededContextFunc) ServeHTTP(e *alerts.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertTransferCreatedContextFunc func(context.Context, *alerts.TransferCreated) error
+
+func (f AlertTransferCreatedContextFunc) ServeHTTP(e *alerts.TransferCreated, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertTransferPaidContextFunc func(context.Context, *alerts.TransferPaid) error
+
+func (f AlertTransferPaidContextFunc) ServeHTTP(e *alerts.TransferPaid, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type AlertUpdateAudienceMemberContextFunc func(context.Context, *alerts.UpdateAudienceMember) error
+
+func (f AlertUpdateAudienceMemberContextFunc) ServeHTTP(e *alerts.UpdateAudienceMember, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type SubscriptionCancelledContextFunc func(context.Context, *subscription.Cancelled) error
+
+func (f SubscriptionCancelledContextFunc) ServeHTTP(e *subscription.Cancelled, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type SubscriptionCreatedContextFunc func(context.Context, *subscription.Created) error
+
+func (f SubscriptionCreatedContextFunc) ServeHTTP(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type SubscriptionPaymentFailedContextFunc func(context.Context, *subscription.PaymentFailed) error
+
+func (f SubscriptionPaymentFailedContextFunc) ServeHTTP(e *subscription.PaymentFailed, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type SubscriptionPaymentRefundedContextFunc func(context.Context, *subscription.PaymentRefunded) error
+
+func (f SubscriptionPaymentRefundedContextFunc) ServeHTTP(e *subscription.PaymentRefunded, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type SubscriptionPaymentSucceededContextFunc func(context.Context, *subscription.PaymentSucceeded) error
+
+func (f SubscriptionPaymentSucceededContextFunc) ServeHTTP(e *subscription.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
+
+type SubscriptionUpdatedContextFunc func(context.Context, *subscription.Updated) error
+
+func (f SubscriptionUpdatedContextFunc) ServeHTTP(e *subscription.Updated, rw http.ResponseWriter, req *http.Request) {
+	writeHandlerError(req, rw, e.GetAlertName(), f(req.Context(), e))
+}
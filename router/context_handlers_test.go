@@ -0,0 +1,91 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func TestContextHandlers(t *testing.T) {
+	newReq := func(query string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(query)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req.WithContext(context.WithValue(req.Context(), contextKey{}, "value"))
+	}
+	errFailed := errors.New("provisioning failed")
+	data := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedLog    bool
+	}{
+		{"Nil", nil, http.StatusOK, false},
+		{"Retryable", Retryable(errFailed), http.StatusServiceUnavailable, false},
+		{"WrappedRetryable", fmt.Errorf("wrapped: %w", Retryable(errFailed)), http.StatusServiceUnavailable, false},
+		{"Permanent", Permanent(errFailed), http.StatusOK, true},
+		{"Unclassified", errFailed, http.StatusInternalServerError, true},
+	}
+	for _, tt := range data {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			var logBuf bytes.Buffer
+			var got *subscription.Created
+			handler := NewRouter(Config{
+				ErrorLog: log.New(&logBuf, "", 0),
+				SubscriptionCreated: SubscriptionCreatedContextFunc(func(ctx context.Context, e *subscription.Created) error {
+					assert.Equal("value", ctx.Value(contextKey{}))
+					got = e
+					return tt.err
+				}),
+			}).Handler()
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newReq("alert_name=subscription_created&alert_id=5"))
+			assert.Equal(tt.expectedStatus, rw.Code)
+			assert.Equal(&subscription.Created{AlertName: "subscription_created", AlertID: 5}, got)
+			assert.NotContains(rw.Body.String(), errFailed.Error())
+			if tt.expectedLog {
+				assert.Contains(logBuf.String(), "subscription_created")
+				assert.Contains(logBuf.String(), errFailed.Error())
+			} else {
+				assert.Empty(logBuf.String())
+			}
+		})
+	}
+
+	t.Run("EventAndRaw", func(t *testing.T) {
+		assert := assert.New(t)
+		registry := events.DefaultRegistry.Clone()
+		registry.Register("custom_event", func() events.Event { return new(customEvent) })
+		handler := NewRouter(Config{
+			Registry: registry,
+			ErrorLog: log.New(ioutil.Discard, "", 0),
+			Handlers: map[string]EventHandler{
+				"custom_event": EventContextFunc(func(ctx context.Context, e events.Event) error {
+					return Retryable(errFailed)
+				}),
+			},
+			Raw: RawContextFunc(func(ctx context.Context, e *events.Raw) error {
+				return Permanent(errFailed)
+			}),
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=custom_event"))
+		assert.Equal(http.StatusServiceUnavailable, rw.Code)
+		rw = httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=brand_new_alert"))
+		assert.Equal(http.StatusOK, rw.Code)
+	})
+}
@@ -0,0 +1,261 @@
+package router
+
+import (
+	"bufio"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/internal/response"
+)
+
+// Deduplicator remembers alerts which were already handled, so alerts
+// redelivered by paddle are acknowledged without calling handler again.
+// Alert is marked as seen only after its handler responded with 2xx status,
+// concurrent deliveries of the same alert may still both reach handler.
+type Deduplicator interface {
+	Seen(key string) (bool, error)
+	MarkSeen(key string) error
+}
+
+// DeduplicationKey returns key identifying e. It is alert name and alert id
+// or, for alerts without alert_id, their p_signature.
+func DeduplicationKey(e events.Event) (string, error) {
+	if id, ok := e.GetAlertID(); ok && id != 0 {
+		return e.GetAlertName() + ":" + strconv.Itoa(id), nil
+	}
+	sig, err := e.Signature()
+	if err != nil {
+		return "", err
+	}
+	return e.GetAlertName() + ":" + string(sig), nil
+}
+
+func deduplicate(d Deduplicator, dispatch func(events.Event, http.ResponseWriter, *http.Request)) func(events.Event, http.ResponseWriter, *http.Request) {
+	return func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
+		key, err := DeduplicationKey(ev)
+		if err == nil {
+			var seen bool
+			seen, err = d.Seen(key)
+			if err == nil && seen {
+				rw.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		if err != nil {
+			logf(req.Context(), "paddle: %s deduplication failed: %v", ev.GetAlertName(), err)
+			writeError(rw, req, http.StatusInternalServerError, err)
+			return
+		}
+		sw := response.NewWriter(rw)
+		dispatch(ev, sw, req)
+		if sw.Failed() {
+			return
+		}
+		if err := d.MarkSeen(key); err != nil {
+			logf(req.Context(), "paddle: %s could not be marked as seen: %v", ev.GetAlertName(), err)
+		}
+	}
+}
+
+// minSweepSize is number of keys below which expired keys are not swept.
+const minSweepSize = 1024
+
+// MemoryDeduplicator keeps seen keys in memory for TTL. Expired keys are
+// swept when number of keys doubles since the last sweep, so marking
+// a key takes amortized constant time.
+type MemoryDeduplicator struct {
+	TTL time.Duration
+	// Now returns current time, time.Now is used if nil.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	seen    map[string]time.Time
+	sweepAt int
+}
+
+func NewMemoryDeduplicator(ttl time.Duration) *MemoryDeduplicator {
+	return &MemoryDeduplicator{TTL: ttl}
+}
+
+func (m *MemoryDeduplicator) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+func (m *MemoryDeduplicator) Seen(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires, ok := m.seen[key]
+	if ok && !m.now().Before(expires) {
+		delete(m.seen, key)
+		ok = false
+	}
+	return ok, nil
+}
+
+func (m *MemoryDeduplicator) MarkSeen(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mark(key, m.now().Add(m.TTL))
+	return nil
+}
+
+// mark stores key and sweeps expired keys if it is due, m.mu must be held.
+// It reports whether keys were swept.
+func (m *MemoryDeduplicator) mark(key string, expires time.Time) bool {
+	if m.seen == nil {
+		m.seen = make(map[string]time.Time)
+	}
+	m.seen[key] = expires
+	if len(m.seen) < m.sweepAt || len(m.seen) < minSweepSize {
+		return false
+	}
+	now := m.now()
+	for k, e := range m.seen {
+		if !now.Before(e) {
+			delete(m.seen, k)
+		}
+	}
+	m.sweepAt = 2 * len(m.seen)
+	return true
+}
+
+// FileDeduplicator is MemoryDeduplicator which also appends seen keys
+// to a file, so they survive restarts. The file is rewritten without
+// expired keys when it is opened and when it holds more than twice as
+// many lines as there are keys left after a sweep.
+type FileDeduplicator struct {
+	MemoryDeduplicator
+	path  string
+	f     *os.File
+	lines int
+}
+
+// NewFileDeduplicator opens or creates file at path and loads keys which
+// have not expired yet.
+func NewFileDeduplicator(path string, ttl time.Duration) (*FileDeduplicator, error) {
+	d := &FileDeduplicator{MemoryDeduplicator: MemoryDeduplicator{TTL: ttl}, path: path}
+	if err := d.load(path); err != nil {
+		return nil, err
+	}
+	if err := d.compact(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// compact rewrites file with keys which have not expired and reopens it
+// for appending, d.mu must be held or d not shared yet.
+func (d *FileDeduplicator) compact() error {
+	tmp := d.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	now := d.now()
+	lines := 0
+	w := bufio.NewWriter(f)
+	for k, e := range d.seen {
+		if now.Before(e) {
+			writeSeen(w, k, e)
+			lines++
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, d.path); err != nil {
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(d.path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	af, err := os.OpenFile(d.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if d.f != nil {
+		d.f.Close()
+	}
+	d.f = af
+	d.lines = lines
+	return nil
+}
+
+func (d *FileDeduplicator) load(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	now := d.now()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		i := strings.LastIndexByte(line, '\t')
+		if i < 0 {
+			continue
+		}
+		unix, err := strconv.ParseInt(line[i+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		if expires := time.Unix(0, unix); now.Before(expires) {
+			d.mark(line[:i], expires)
+		}
+	}
+	return s.Err()
+}
+
+func writeSeen(w *bufio.Writer, key string, expires time.Time) {
+	w.WriteString(key)
+	w.WriteByte('\t')
+	w.WriteString(strconv.FormatInt(expires.UnixNano(), 10))
+	w.WriteByte('\n')
+}
+
+func (d *FileDeduplicator) MarkSeen(key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	expires := d.now().Add(d.TTL)
+	var buf strings.Builder
+	w := bufio.NewWriter(&buf)
+	writeSeen(w, key, expires)
+	w.Flush()
+	if _, err := d.f.WriteString(buf.String()); err != nil {
+		return err
+	}
+	if err := d.f.Sync(); err != nil {
+		return err
+	}
+	d.lines++
+	if d.mark(key, expires) && d.lines > 2*len(d.seen) {
+		return d.compact()
+	}
+	return nil
+}
+
+// Close closes underlying file.
+func (d *FileDeduplicator) Close() error {
+	return d.f.Close()
+}
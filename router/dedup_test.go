@@ -0,0 +1,138 @@
+package router
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/alerts"
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicationKey(t *testing.T) {
+	assert := assert.New(t)
+	key, err := DeduplicationKey(&subscription.PaymentSucceeded{AlertName: "subscription_payment_succeeded", AlertID: 12})
+	assert.NoError(err)
+	assert.Equal("subscription_payment_succeeded:12", key)
+	key, err = DeduplicationKey(&alerts.TransferPaid{AlertName: "transfer_paid", PSignature: "c2ln"})
+	assert.NoError(err)
+	assert.Equal("transfer_paid:c2ln", key)
+	key, err = DeduplicationKey(&events.Raw{AlertName: "brand_new_alert", Fields: events.Fields{"alert_id": "3"}})
+	assert.NoError(err)
+	assert.Equal("brand_new_alert:3", key)
+}
+
+func TestMemoryDeduplicator(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	d := NewMemoryDeduplicator(time.Minute)
+	d.Now = func() time.Time { return now }
+	seen, err := d.Seen("a")
+	assert.NoError(err)
+	assert.False(seen)
+	assert.NoError(d.MarkSeen("a"))
+	seen, _ = d.Seen("a")
+	assert.True(seen)
+	now = now.Add(time.Minute)
+	seen, _ = d.Seen("a")
+	assert.False(seen)
+	assert.NoError(d.MarkSeen("b"))
+	assert.Len(d.seen, 1, "expired keys must be dropped")
+
+	for i := 0; i < minSweepSize; i++ {
+		assert.NoError(d.MarkSeen(strconv.Itoa(i)))
+	}
+	now = now.Add(time.Minute)
+	for i := 0; i < minSweepSize; i++ {
+		assert.NoError(d.MarkSeen("new" + strconv.Itoa(i)))
+	}
+	assert.True(len(d.seen) < 2*minSweepSize, "expired keys must be swept")
+}
+
+func TestFileDeduplicator(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "dedup")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "seen")
+
+	d, err := NewFileDeduplicator(path, time.Hour)
+	require.NoError(err)
+	assert.NoError(d.MarkSeen("subscription_created:1"))
+	d.TTL = -time.Second
+	assert.NoError(d.MarkSeen("subscription_created:2"))
+	require.NoError(d.Close())
+
+	d, err = NewFileDeduplicator(path, time.Hour)
+	require.NoError(err)
+	defer d.Close()
+	seen, err := d.Seen("subscription_created:1")
+	assert.NoError(err)
+	assert.True(seen)
+	seen, _ = d.Seen("subscription_created:2")
+	assert.False(seen)
+	b, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	assert.NotContains(string(b), "subscription_created:2", "expired keys must be compacted")
+
+	now := time.Now()
+	d.Now = func() time.Time { return now }
+	d.TTL = time.Minute
+	for i := 0; i < 4*minSweepSize; i++ {
+		if i == 2*minSweepSize {
+			now = now.Add(time.Hour)
+		}
+		require.NoError(d.MarkSeen("subscription_created:" + strconv.Itoa(i+10)))
+	}
+	b, err = ioutil.ReadFile(path)
+	require.NoError(err)
+	lines := bytes.Count(b, []byte("\n"))
+	assert.True(lines < 4*minSweepSize, "file must be compacted while open, has %d lines", lines)
+	require.NoError(d.MarkSeen("subscription_created:last"))
+	seen, _ = d.Seen("subscription_created:last")
+	assert.True(seen, "file must stay writable after compaction")
+}
+
+func TestRouterDeduplicator(t *testing.T) {
+	newReq := func(query string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(query)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+	assert := assert.New(t)
+	status := http.StatusInternalServerError
+	calls := 0
+	handler := NewRouter(Config{
+		ErrorLog:     log.New(ioutil.Discard, "", 0),
+		Deduplicator: NewMemoryDeduplicator(time.Hour),
+		SubscriptionPaymentSucceeded: SubscriptionPaymentSucceededFunc(func(e *subscription.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
+			calls++
+			rw.WriteHeader(status)
+		}),
+	}).Handler()
+	serve := func(query string) int {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq(query))
+		return rw.Code
+	}
+	const alert = "alert_name=subscription_payment_succeeded&alert_id=12"
+	assert.Equal(http.StatusInternalServerError, serve(alert))
+	status = http.StatusOK
+	assert.Equal(http.StatusOK, serve(alert), "failed alert must not be marked as seen")
+	assert.Equal(http.StatusOK, serve(alert))
+	assert.Equal(2, calls, "duplicate must not reach handler")
+	assert.Equal(http.StatusOK, serve("alert_name=subscription_payment_succeeded&alert_id=13"))
+	assert.Equal(3, calls)
+}
@@ -0,0 +1,133 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/dennor/go-paddle/middleware"
+)
+
+// RetryableError marks handler error as temporary. Router responds
+// with 503 Service Unavailable, so paddle delivers the alert again.
+type RetryableError struct {
+	Err error
+}
+
+func (r RetryableError) Error() string {
+	return r.Err.Error()
+}
+
+func (r RetryableError) Unwrap() error {
+	return r.Err
+}
+
+// Retryable wraps err in RetryableError, nil stays nil.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return RetryableError{err}
+}
+
+// PermanentError marks handler error as one that will not go away on
+// redelivery. Router logs it and acknowledges the alert with 200 OK,
+// so paddle stops retrying.
+type PermanentError struct {
+	Err error
+}
+
+func (p PermanentError) Error() string {
+	return p.Err.Error()
+}
+
+func (p PermanentError) Unwrap() error {
+	return p.Err
+}
+
+// Permanent wraps err in PermanentError, nil stays nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return PermanentError{err}
+}
+
+type errorLogKey struct{}
+
+type errorHandlerKey struct{}
+
+var (
+	errQueueFull   = errors.New("event queue is full")
+	errQueueClosed = errors.New("event queue is shut down")
+)
+
+// handlerError is error which occurred while dispatching event,
+// it is answered with status.
+type handlerError struct {
+	status int
+	err    error
+}
+
+func (h handlerError) Error() string {
+	return h.err.Error()
+}
+
+func (h handlerError) Unwrap() error {
+	return h.err
+}
+
+func (h handlerError) Status() int {
+	return h.status
+}
+
+// writeError writes response with status for err which occurred while
+// dispatching event, using Config.ErrorHandler if it is set.
+func writeError(rw http.ResponseWriter, req *http.Request, status int, err error) {
+	if h, ok := req.Context().Value(errorHandlerKey{}).(middleware.ErrorHandler); ok {
+		h(rw, req, handlerError{status, err})
+		return
+	}
+	http.Error(rw, http.StatusText(status), status)
+}
+
+// handlerNotFound responds to events without handler with 404 Not Found.
+func handlerNotFound(rw http.ResponseWriter, req *http.Request, ename string) {
+	err := errors.New("missing handler for event " + ename)
+	if _, ok := req.Context().Value(errorHandlerKey{}).(middleware.ErrorHandler); ok {
+		writeError(rw, req, http.StatusNotFound, err)
+		return
+	}
+	http.Error(rw, err.Error(), http.StatusNotFound)
+}
+
+func logf(ctx context.Context, format string, args ...interface{}) {
+	if l, ok := ctx.Value(errorLogKey{}).(*log.Logger); ok {
+		l.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// writeHandlerError maps error returned by handler to response status.
+// Nil is 200, PermanentError is logged and acknowledged with 200,
+// RetryableError is 503 and any other error is logged and answered with 500.
+// Error messages are never written to response.
+func writeHandlerError(req *http.Request, rw http.ResponseWriter, ename string, err error) {
+	ctx := req.Context()
+	var permanent PermanentError
+	var retryable RetryableError
+	switch {
+	case err == nil:
+		rw.WriteHeader(http.StatusOK)
+	case errors.As(err, &permanent):
+		logf(ctx, "paddle: %s handler failed permanently, acknowledging: %v", ename, err)
+		rw.WriteHeader(http.StatusOK)
+	case errors.As(err, &retryable):
+		writeError(rw, req, http.StatusServiceUnavailable, err)
+	default:
+		logf(ctx, "paddle: %s handler failed: %v", ename, err)
+		writeError(rw, req, http.StatusInternalServerError, err)
+	}
+}
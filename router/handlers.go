@@ -3,10 +3,34 @@ package router
 import (
 	"net/http"
 
+	"github.com/dennor/go-paddle/events"
 	"github.com/dennor/go-paddle/events/alerts"
 	"github.com/dennor/go-paddle/events/subscription"
 )
 
+// EventHandler handles events of types registered in events.Registry,
+// which have no dedicated handler type.
+type EventHandler interface {
+	ServeHTTP(events.Event, http.ResponseWriter, *http.Request)
+}
+
+type EventHandlerFunc func(events.Event, http.ResponseWriter, *http.Request)
+
+func (f EventHandlerFunc) ServeHTTP(e events.Event, rw http.ResponseWriter, req *http.Request) {
+	f(e, rw, req)
+}
+
+// Raw handles alerts with names not found in events.Registry.
+type Raw interface {
+	ServeHTTP(*events.Raw, http.ResponseWriter, *http.Request)
+}
+
+type RawFunc func(*events.Raw, http.ResponseWriter, *http.Request)
+
+func (f RawFunc) ServeHTTP(e *events.Raw, rw http.ResponseWriter, req *http.Request) {
+	f(e, rw, req)
+}
+
 type AlertHighRiskTransactionCreated interface {
 	ServeHTTP(*alerts.HighRiskTransactionCreated, http.ResponseWriter, *http.Request)
 }
@@ -176,77 +200,3 @@ type SubscriptionUpdatedFunc func(*subscription.Updated, http.ResponseWriter, *h
 func (f SubscriptionUpdatedFunc) ServeHTTP(e *subscription.Updated, rw http.ResponseWriter, req *http.Request) {
 	f(e, rw, req)
 }
-
-func handlerNotFound(rw http.ResponseWriter, ename string) {
-	http.Error(rw, "missing handler for event "+ename, http.StatusNotFound)
-}
-
-var (
-	alertHighRiskTransactionCreatedNotFound = AlertHighRiskTransactionCreatedFunc(func(e *alerts.HighRiskTransactionCreated, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.HighRiskTransactionCreatedAlertName)
-	})
-
-	alertHighRiskTransactionUpdatedNotFound = AlertHighRiskTransactionUpdatedFunc(func(e *alerts.HighRiskTransactionUpdated, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.HighRiskTransactionUpdatedAlertName)
-	})
-
-	alertLockerProcessedNotFound = AlertLockerProcessedFunc(func(e *alerts.LockerProcessed, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.LockerProcessedAlertName)
-	})
-
-	alertNewAudienceMemberNotFound = AlertNewAudienceMemberFunc(func(e *alerts.NewAudienceMember, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.NewAudienceMemberAlertName)
-	})
-
-	alertPaymentDisputeClosedNotFound = AlertPaymentDisputeClosedFunc(func(e *alerts.PaymentDisputeClosed, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.PaymentDisputeClosedAlertName)
-	})
-
-	alertPaymentDisputeCreatedNotFound = AlertPaymentDisputeCreatedFunc(func(e *alerts.PaymentDisputeCreated, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.PaymentDisputeCreatedAlertName)
-	})
-
-	alertPaymentRefundedNotFound = AlertPaymentRefundedFunc(func(e *alerts.PaymentRefunded, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.PaymentRefundedAlertName)
-	})
-
-	alertPaymentSucceededNotFound = AlertPaymentSucceededFunc(func(e *alerts.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.PaymentSucceededAlertName)
-	})
-
-	alertTransferCreatedNotFound = AlertTransferCreatedFunc(func(e *alerts.TransferCreated, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.TransferCreatedAlertName)
-	})
-
-	alertTransferPaidNotFound = AlertTransferPaidFunc(func(e *alerts.TransferPaid, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.TransferPaidAlertName)
-	})
-
-	alertUpdateAudienceMemberNotFound = AlertUpdateAudienceMemberFunc(func(e *alerts.UpdateAudienceMember, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, alerts.UpdateAudienceMemberAlertName)
-	})
-
-	susbcriptionCancelledNotFound = SubscriptionCancelledFunc(func(e *subscription.Cancelled, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, subscription.CancelledAlertName)
-	})
-
-	susbcriptionCreatedNotFound = SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, subscription.CreatedAlertName)
-	})
-
-	susbcriptionPaymentFailedNotFound = SubscriptionPaymentFailedFunc(func(e *subscription.PaymentFailed, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, subscription.PaymentFailedAlertName)
-	})
-
-	susbcriptionPaymentRefundedNotFound = SubscriptionPaymentRefundedFunc(func(e *subscription.PaymentRefunded, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, subscription.PaymentRefundedAlertName)
-	})
-
-	susbcriptionPaymentSucceededNotFound = SubscriptionPaymentSucceededFunc(func(e *subscription.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, subscription.PaymentSucceededAlertName)
-	})
-
-	susbcriptionUpdatedNotFound = SubscriptionUpdatedFunc(func(e *subscription.Updated, rw http.ResponseWriter, req *http.Request) {
-		handlerNotFound(rw, subscription.UpdatedAlertName)
-	})
-)
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/internal/response"
+)
+
+// Interceptor wraps handling of every decoded event, before it is
+// dispatched to handler of its type. Interceptor may answer the alert
+// itself without calling next, pass request with enriched context
+// to next, or observe response status with ResponseStatus after next
+// returns.
+type Interceptor func(next EventHandler) EventHandler
+
+// ResponseStatus returns status written so far to rw passed to
+// Interceptor, 200 if nothing was written.
+func ResponseStatus(rw http.ResponseWriter) int {
+	if s, ok := rw.(interface{ Status() int }); ok && s.Status() != 0 {
+		return s.Status()
+	}
+	return http.StatusOK
+}
+
+// withInterceptors wraps dispatch in Interceptors of r and, for events
+// of their environment, in Interceptors of Environments.
+func (r Router) withInterceptors(dispatch func(events.Event, http.ResponseWriter, *http.Request)) func(events.Event, http.ResponseWriter, *http.Request) {
+	byEnv := make(map[events.Environment]func(events.Event, http.ResponseWriter, *http.Request))
+	for env, c := range r.Environments {
+		if len(c.Interceptors) > 0 {
+			byEnv[env] = intercept(c.Interceptors, dispatch)
+		}
+	}
+	if len(byEnv) == 0 {
+		return intercept(r.Interceptors, dispatch)
+	}
+	return intercept(r.Interceptors, func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
+		env, _ := events.EnvironmentFromContext(req.Context())
+		if envDispatch, ok := byEnv[env]; ok {
+			envDispatch(ev, rw, req)
+			return
+		}
+		dispatch(ev, rw, req)
+	})
+}
+
+// intercept wraps dispatch in interceptors, first of them is the outermost.
+func intercept(interceptors []Interceptor, dispatch func(events.Event, http.ResponseWriter, *http.Request)) func(events.Event, http.ResponseWriter, *http.Request) {
+	if len(interceptors) == 0 {
+		return dispatch
+	}
+	var h EventHandler = EventHandlerFunc(dispatch)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		h = interceptors[i](h)
+	}
+	return func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
+		h.ServeHTTP(ev, response.NewWriter(rw), req)
+	}
+}
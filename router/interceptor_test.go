@@ -0,0 +1,119 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantKey struct{}
+
+func TestInterceptors(t *testing.T) {
+	newReq := func(passthrough string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created&passthrough="+passthrough)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+	var trace []string
+	var tenants []string
+	observe := func(name string) Interceptor {
+		return func(next EventHandler) EventHandler {
+			return EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+				trace = append(trace, name+" before")
+				next.ServeHTTP(e, rw, req)
+				trace = append(trace, name+" after "+http.StatusText(ResponseStatus(rw)))
+			})
+		}
+	}
+	tenant := func(next EventHandler) EventHandler {
+		return EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+			if e.GetPassthrough() == "" {
+				http.Error(rw, "missing tenant", http.StatusUnprocessableEntity)
+				return
+			}
+			next.ServeHTTP(e, rw, req.WithContext(context.WithValue(req.Context(), tenantKey{}, e.GetPassthrough())))
+		})
+	}
+	handler := NewRouter(Config{
+		Interceptors: []Interceptor{observe("outer"), tenant, observe("inner")},
+		SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+			tenants = append(tenants, req.Context().Value(tenantKey{}).(string))
+			rw.WriteHeader(http.StatusAccepted)
+		}),
+	}).Handler()
+
+	t.Run("Chain", func(t *testing.T) {
+		assert := assert.New(t)
+		trace, tenants = nil, nil
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("acme"))
+		assert.Equal(http.StatusAccepted, rw.Code)
+		assert.Equal([]string{"outer before", "inner before", "inner after Accepted", "outer after Accepted"}, trace)
+		assert.Equal([]string{"acme"}, tenants)
+	})
+
+	t.Run("ShortCircuit", func(t *testing.T) {
+		assert := assert.New(t)
+		trace, tenants = nil, nil
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq(""))
+		assert.Equal(http.StatusUnprocessableEntity, rw.Code)
+		assert.Equal([]string{"outer before", "outer after Unprocessable Entity"}, trace)
+		assert.Empty(tenants)
+	})
+
+	t.Run("ResponseStatusDefault", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, ResponseStatus(httptest.NewRecorder()))
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		assert := assert.New(t)
+		trace, tenants = nil, nil
+		r := NewRouter(Config{
+			Workers:      1,
+			Interceptors: []Interceptor{observe("outer"), tenant},
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+				tenants = append(tenants, req.Context().Value(tenantKey{}).(string))
+			}),
+		})
+		handler := r.Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq(""))
+		assert.Equal(http.StatusUnprocessableEntity, rw.Code, "interceptor must reject event before it is acknowledged")
+		rw = httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("acme"))
+		assert.Equal(http.StatusOK, rw.Code)
+		assert.NoError(r.Shutdown(context.Background()))
+		assert.Equal([]string{"outer before", "outer after Unprocessable Entity", "outer before", "outer after OK"}, trace)
+		assert.Equal([]string{"acme"}, tenants)
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		assert := assert.New(t)
+		trace, tenants = nil, nil
+		created := SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {})
+		handler := NewRouter(Config{
+			Verifier:            passthroughEnvironment{},
+			Interceptors:        []Interceptor{observe("outer")},
+			SubscriptionCreated: created,
+			Environments: map[events.Environment]Config{
+				"sandbox": {
+					Interceptors:        []Interceptor{observe("sandbox")},
+					SubscriptionCreated: created,
+				},
+			},
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("sandbox"))
+		assert.Equal(http.StatusOK, rw.Code)
+		handler.ServeHTTP(httptest.NewRecorder(), newReq("production"))
+		assert.Equal([]string{"outer before", "sandbox before", "sandbox after OK", "outer after OK", "outer before", "outer after OK"}, trace)
+	})
+}
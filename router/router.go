@@ -1,19 +1,95 @@
 package router
 
 import (
+	"context"
+	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/dennor/go-paddle/events"
 	"github.com/dennor/go-paddle/events/alerts"
 	"github.com/dennor/go-paddle/events/subscription"
 	"github.com/dennor/go-paddle/httperrors"
+	"github.com/dennor/go-paddle/internal/response"
 	"github.com/dennor/go-paddle/middleware"
-	"github.com/dennor/go-paddle/signature"
 )
 
+// Config configures Router.
+//
+// Registry is used to decode events, events.DefaultRegistry is used if nil.
+// Handlers maps alert names of events registered in Registry, which have
+// no dedicated handler field, to their handlers. If Raw is set, alerts
+// with names unknown to Registry are passed to it as *events.Raw instead
+// of being rejected. ErrorLog receives errors returned by context handlers,
+// standard logger is used if nil. Unhandled decides what happens with
+// events which have no handler, CatchAll receives them if Unhandled is
+// UnhandledCatchAll.
+//
+// If Workers is positive, events are verified and decoded while serving
+// request, but handlers are run on Workers goroutines after alert was
+// acknowledged. At most QueueSize events wait for a worker (Workers if
+// QueueSize is not positive), when queue is full router responds with
+// 503 Service Unavailable so paddle retries later. Responses written by
+// handlers are only logged in this mode.
+//
+// If Deduplicator is set, alerts which were already handled successfully
+// are acknowledged without calling handler.
+//
+// If Journal is set, every verified alert is persisted in it before
+// it is dispatched, see middleware.EventConfig.
+//
+// MaxBodyBytes limits size of alert request body and IPAllowlist
+// addresses alerts are accepted from, see middleware.EventConfig.
+//
+// Interceptors wrap handling of every event accepted by router, see
+// Interceptor. The first one is the outermost, they run after
+// deduplication. If Workers is positive, they run before the event is
+// queued instead, so they can still reject it, but next returns once the
+// event is queued and ResponseStatus reports its acknowledgement.
+//
+// ErrorHandler, if set, writes responses for rejected alerts, unhandled
+// alerts, full event queue, deduplication failures and errors returned by
+// context handlers, e.g. middleware.WriteProblem.
+//
+// If Freshness is set, stale alerts are rejected, redeliveries of alerts
+// still being handled are answered with 409 Conflict and replayed ones are
+// acknowledged without calling handlers, see middleware.Freshness. Alerts
+// are accepted by it once they are acknowledged, alerts whose handlers
+// fail are forgotten, so paddle can retry them.
+// Freshness runs before Deduplicator, with default ReplayStatus paddle's
+// redelivery of a handled alert is acknowledged by whichever of them
+// remembers it, Freshness only does so while signature is in its cache.
+//
+// If Verifier is events.EnvironmentVerifier, environment which signed
+// the event is stored in request context, see events.EnvironmentFromContext.
+// EnvironmentPolicies decide whether events from an environment are
+// accepted at all, events of environment which has entry in Environments
+// are passed to handlers from that entry instead of c. Only handler
+// fields, Handlers, Raw, Unhandled, CatchAll and Interceptors of entries
+// are used, Registry defaults to the one of c. Interceptors of entry run
+// inside Interceptors of c. Empty environment stands for events
+// verified by a verifier which does not report environment.
 type Config struct {
 	Verifier                        events.Verifier
 	CopyBody                        bool
+	VerifyFields                    bool
+	Registry                        *events.Registry
+	Handlers                        map[string]EventHandler
+	Raw                             Raw
+	ErrorLog                        *log.Logger
+	Unhandled                       UnhandledPolicy
+	CatchAll                        EventHandler
+	Workers                         int
+	QueueSize                       int
+	Deduplicator                    Deduplicator
+	Journal                         middleware.Journal
+	Freshness                       *middleware.Freshness
+	MaxBodyBytes                    int64
+	IPAllowlist                     *middleware.IPAllowlist
+	ErrorHandler                    middleware.ErrorHandler
+	Interceptors                    []Interceptor
+	Environments                    map[events.Environment]Config
+	EnvironmentPolicies             map[events.Environment]EnvironmentPolicy
 	AlertHighRiskTransactionCreated AlertHighRiskTransactionCreated
 	AlertHighRiskTransactionUpdated AlertHighRiskTransactionUpdated
 	AlertLockerProcessed            AlertLockerProcessed
@@ -33,6 +109,96 @@ type Config struct {
 	SubscriptionUpdated             SubscriptionUpdated
 }
 
+// UnhandledPolicy selects how Router responds to events without handler.
+type UnhandledPolicy int
+
+const (
+	// UnhandledNotFound responds with 404 Not Found, paddle keeps
+	// retrying the alert.
+	UnhandledNotFound UnhandledPolicy = iota
+	// UnhandledAcknowledge responds with 200 OK and drops the event.
+	UnhandledAcknowledge
+	// UnhandledCatchAll passes the event to Config.CatchAll, alerts
+	// unknown to Registry are passed as *events.Raw. Falls back to
+	// UnhandledNotFound if CatchAll is nil.
+	UnhandledCatchAll
+)
+
+// EnvironmentPolicy selects how Router responds to events signed
+// in an environment.
+type EnvironmentPolicy int
+
+const (
+	// EnvironmentAccept passes events to handlers.
+	EnvironmentAccept EnvironmentPolicy = iota
+	// EnvironmentReject responds with 403 Forbidden.
+	EnvironmentReject
+	// EnvironmentAcknowledge responds with 200 OK and drops the event.
+	EnvironmentAcknowledge
+)
+
+// acceptsUnknown reports whether c or any of its environments
+// handles alerts unknown to registry.
+func (c Config) acceptsUnknown() bool {
+	if c.Raw != nil || c.Unhandled != UnhandledNotFound {
+		return true
+	}
+	for _, ec := range c.Environments {
+		if ec.acceptsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Config) unhandled() EventHandler {
+	switch {
+	case c.Unhandled == UnhandledAcknowledge:
+		return EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusOK)
+		})
+	case c.Unhandled == UnhandledCatchAll && c.CatchAll != nil:
+		return c.CatchAll
+	}
+	return EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+		handlerNotFound(rw, req, e.GetAlertName())
+	})
+}
+
+// MissingHandlers returns names of built-in alerts which have no handler
+// in c. It is meant to be logged on startup.
+func (c Config) MissingHandlers() []string {
+	handlers := []struct {
+		name string
+		set  bool
+	}{
+		{alerts.HighRiskTransactionCreatedAlertName, c.AlertHighRiskTransactionCreated != nil},
+		{alerts.HighRiskTransactionUpdatedAlertName, c.AlertHighRiskTransactionUpdated != nil},
+		{alerts.LockerProcessedAlertName, c.AlertLockerProcessed != nil},
+		{alerts.NewAudienceMemberAlertName, c.AlertNewAudienceMember != nil},
+		{alerts.PaymentDisputeClosedAlertName, c.AlertPaymentDisputeClosed != nil},
+		{alerts.PaymentDisputeCreatedAlertName, c.AlertPaymentDisputeCreated != nil},
+		{alerts.PaymentRefundedAlertName, c.AlertPaymentRefunded != nil},
+		{alerts.PaymentSucceededAlertName, c.AlertPaymentSucceeded != nil},
+		{alerts.TransferCreatedAlertName, c.AlertTransferCreated != nil},
+		{alerts.TransferPaidAlertName, c.AlertTransferPaid != nil},
+		{alerts.UpdateAudienceMemberAlertName, c.AlertUpdateAudienceMember != nil},
+		{subscription.CancelledAlertName, c.SubscriptionCancelled != nil},
+		{subscription.CreatedAlertName, c.SubscriptionCreated != nil},
+		{subscription.PaymentFailedAlertName, c.SubscriptionPaymentFailed != nil},
+		{subscription.PaymentRefundedAlertName, c.SubscriptionPaymentRefunded != nil},
+		{subscription.PaymentSucceededAlertName, c.SubscriptionPaymentSucceeded != nil},
+		{subscription.UpdatedAlertName, c.SubscriptionUpdated != nil},
+	}
+	var missing []string
+	for _, h := range handlers {
+		if !h.set {
+			missing = append(missing, h.name)
+		}
+	}
+	return missing
+}
+
 type Router struct {
 	Config
 	alertHighRiskTransactionCreated AlertHighRiskTransactionCreated
@@ -52,98 +218,128 @@ type Router struct {
 	subscriptionPaymentRefunded     SubscriptionPaymentRefunded
 	subscriptionPaymentSucceeded    SubscriptionPaymentSucceeded
 	subscriptionUpdated             SubscriptionUpdated
+	registry                        *events.Registry
 	ev                              middleware.Event
+	queue                           *queue
 }
 
-func (r Router) Handler() http.Handler {
-	r.ev.Verifier = r.Config.Verifier
-	r.ev.SkipContext = true
-	r.ev.CopyBody = r.CopyBody
+// dispatcher returns function passing event to its handler from r.
+func (r Router) dispatcher() func(events.Event, http.ResponseWriter, *http.Request) {
+	r.registry = r.Registry
+	if r.registry == nil {
+		r.registry = events.DefaultRegistry
+	}
+	unhandled := r.unhandled()
 	r.alertHighRiskTransactionCreated = r.AlertHighRiskTransactionCreated
 	if r.alertHighRiskTransactionCreated == nil {
-		r.alertHighRiskTransactionCreated = alertHighRiskTransactionCreatedNotFound
+		r.alertHighRiskTransactionCreated = AlertHighRiskTransactionCreatedFunc(func(e *alerts.HighRiskTransactionCreated, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertHighRiskTransactionUpdated = r.AlertHighRiskTransactionUpdated
 	if r.alertHighRiskTransactionUpdated == nil {
-		r.alertHighRiskTransactionUpdated = alertHighRiskTransactionUpdatedNotFound
+		r.alertHighRiskTransactionUpdated = AlertHighRiskTransactionUpdatedFunc(func(e *alerts.HighRiskTransactionUpdated, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertLockerProcessed = r.AlertLockerProcessed
 	if r.alertLockerProcessed == nil {
-		r.alertLockerProcessed = alertLockerProcessedNotFound
+		r.alertLockerProcessed = AlertLockerProcessedFunc(func(e *alerts.LockerProcessed, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertNewAudienceMember = r.AlertNewAudienceMember
 	if r.alertNewAudienceMember == nil {
-		r.alertNewAudienceMember = alertNewAudienceMemberNotFound
+		r.alertNewAudienceMember = AlertNewAudienceMemberFunc(func(e *alerts.NewAudienceMember, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertPaymentDisputeClosed = r.AlertPaymentDisputeClosed
 	if r.alertPaymentDisputeClosed == nil {
-		r.alertPaymentDisputeClosed = alertPaymentDisputeClosedNotFound
+		r.alertPaymentDisputeClosed = AlertPaymentDisputeClosedFunc(func(e *alerts.PaymentDisputeClosed, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertPaymentDisputeCreated = r.AlertPaymentDisputeCreated
 	if r.alertPaymentDisputeCreated == nil {
-		r.alertPaymentDisputeCreated = alertPaymentDisputeCreatedNotFound
+		r.alertPaymentDisputeCreated = AlertPaymentDisputeCreatedFunc(func(e *alerts.PaymentDisputeCreated, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertPaymentRefunded = r.AlertPaymentRefunded
 	if r.alertPaymentRefunded == nil {
-		r.alertPaymentRefunded = alertPaymentRefundedNotFound
+		r.alertPaymentRefunded = AlertPaymentRefundedFunc(func(e *alerts.PaymentRefunded, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertPaymentSucceeded = r.AlertPaymentSucceeded
 	if r.alertPaymentSucceeded == nil {
-		r.alertPaymentSucceeded = alertPaymentSucceededNotFound
+		r.alertPaymentSucceeded = AlertPaymentSucceededFunc(func(e *alerts.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertTransferCreated = r.AlertTransferCreated
 	if r.alertTransferCreated == nil {
-		r.alertTransferCreated = alertTransferCreatedNotFound
+		r.alertTransferCreated = AlertTransferCreatedFunc(func(e *alerts.TransferCreated, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertTransferPaid = r.AlertTransferPaid
 	if r.alertTransferPaid == nil {
-		r.alertTransferPaid = alertTransferPaidNotFound
+		r.alertTransferPaid = AlertTransferPaidFunc(func(e *alerts.TransferPaid, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.alertUpdateAudienceMember = r.AlertUpdateAudienceMember
 	if r.alertUpdateAudienceMember == nil {
-		r.alertUpdateAudienceMember = alertUpdateAudienceMemberNotFound
+		r.alertUpdateAudienceMember = AlertUpdateAudienceMemberFunc(func(e *alerts.UpdateAudienceMember, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.subscriptionCancelled = r.SubscriptionCancelled
 	if r.subscriptionCancelled == nil {
-		r.subscriptionCancelled = susbcriptionCancelledNotFound
+		r.subscriptionCancelled = SubscriptionCancelledFunc(func(e *subscription.Cancelled, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.subscriptionCreated = r.SubscriptionCreated
 	if r.subscriptionCreated == nil {
-		r.subscriptionCreated = susbcriptionCreatedNotFound
+		r.subscriptionCreated = SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.subscriptionPaymentFailed = r.SubscriptionPaymentFailed
 	if r.subscriptionPaymentFailed == nil {
-		r.subscriptionPaymentFailed = susbcriptionPaymentFailedNotFound
+		r.subscriptionPaymentFailed = SubscriptionPaymentFailedFunc(func(e *subscription.PaymentFailed, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.subscriptionPaymentRefunded = r.SubscriptionPaymentRefunded
 	if r.subscriptionPaymentRefunded == nil {
-		r.subscriptionPaymentRefunded = susbcriptionPaymentRefundedNotFound
+		r.subscriptionPaymentRefunded = SubscriptionPaymentRefundedFunc(func(e *subscription.PaymentRefunded, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.subscriptionPaymentSucceeded = r.SubscriptionPaymentSucceeded
 	if r.subscriptionPaymentSucceeded == nil {
-		r.subscriptionPaymentSucceeded = susbcriptionPaymentSucceededNotFound
+		r.subscriptionPaymentSucceeded = SubscriptionPaymentSucceededFunc(func(e *subscription.PaymentSucceeded, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
 	r.subscriptionUpdated = r.SubscriptionUpdated
 	if r.subscriptionUpdated == nil {
-		r.subscriptionUpdated = susbcriptionUpdatedNotFound
+		r.subscriptionUpdated = SubscriptionUpdatedFunc(func(e *subscription.Updated, rw http.ResponseWriter, req *http.Request) {
+			unhandled.ServeHTTP(e, rw, req)
+		})
 	}
-	getEvent := r.ev.EventFromRequest()
-	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		ev, err := getEvent(req)
-		if err != nil {
-			var httpError httperrors.Error
-			switch terr := err.(type) {
-			case httperrors.Error:
-				httpError = terr
-			case signature.VerificationError:
-				httpError = httperrors.NewBadRequestError(err.Error())
-			default:
-				httpError = httperrors.NewBadRequestError(err.Error())
-			}
-			httpError.WriteTo(rw)
-			return
-		}
+	return func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
 		switch tev := ev.(type) {
+		case *events.Raw:
+			if r.Raw == nil {
+				unhandled.ServeHTTP(tev, rw, req)
+				return
+			}
+			r.Raw.ServeHTTP(tev, rw, req)
 		case *alerts.HighRiskTransactionCreated:
 			r.alertHighRiskTransactionCreated.ServeHTTP(tev, rw, req)
 		case *alerts.HighRiskTransactionUpdated:
@@ -178,10 +374,149 @@ func (r Router) Handler() http.Handler {
 			r.subscriptionPaymentSucceeded.ServeHTTP(tev, rw, req)
 		case *subscription.Updated:
 			r.subscriptionUpdated.ServeHTTP(tev, rw, req)
+		default:
+			name, _ := r.registry.Name(ev)
+			if h, ok := r.Handlers[name]; ok {
+				h.ServeHTTP(ev, rw, req)
+				return
+			}
+			unhandled.ServeHTTP(ev, rw, req)
+		}
+	}
+}
+
+// writeError writes response for err which rejected req.
+func (r Router) writeError(rw http.ResponseWriter, req *http.Request, err error) {
+	writeRejection(r.ErrorHandler, rw, req, err)
+}
+
+// writeRejection writes response for err which rejected req, using
+// handler h if it is set.
+func writeRejection(h middleware.ErrorHandler, rw http.ResponseWriter, req *http.Request, err error) {
+	// errors with success status, e.g. replays, acknowledge the alert
+	if h != nil && httperrors.StatusCode(err) >= http.StatusBadRequest {
+		h(rw, req, err)
+		return
+	}
+	httpError, ok := err.(httperrors.Error)
+	if !ok {
+		httpError = httperrors.NewInternalServerError(http.StatusText(http.StatusInternalServerError))
+	}
+	httpError.WriteTo(rw)
+}
+
+// forgetFailed returns dispatch which passes events its handlers
+// failed to f.Forget.
+func forgetFailed(f *middleware.Freshness, dispatch func(events.Event, http.ResponseWriter, *http.Request)) func(events.Event, http.ResponseWriter, *http.Request) {
+	return func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
+		sw := response.NewWriter(rw)
+		dispatch(ev, sw, req)
+		if sw.Failed() {
+			f.Forget(ev)
 		}
+	}
+}
+
+// environmentDispatcher returns function passing event to dispatcher
+// of its environment, or to fallback if environment has no config.
+func (r Router) environmentDispatcher(fallback func(events.Event, http.ResponseWriter, *http.Request)) func(events.Event, http.ResponseWriter, *http.Request) {
+	dispatchers := make(map[events.Environment]func(events.Event, http.ResponseWriter, *http.Request), len(r.Environments))
+	for env, c := range r.Environments {
+		if c.Registry == nil {
+			c.Registry = r.registry
+		}
+		dispatchers[env] = Router{Config: c}.dispatcher()
+	}
+	return func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
+		env, _ := events.EnvironmentFromContext(req.Context())
+		if dispatch, ok := dispatchers[env]; ok {
+			dispatch(ev, rw, req)
+			return
+		}
+		fallback(ev, rw, req)
+	}
+}
+
+func (r Router) Handler() http.Handler {
+	r.ev.Verifier = r.Config.Verifier
+	r.ev.SkipContext = true
+	r.ev.CopyBody = r.CopyBody
+	r.ev.VerifyFields = r.VerifyFields
+	r.ev.Journal = r.Journal
+	r.ev.Freshness = r.Freshness
+	r.ev.MaxBodyBytes = r.MaxBodyBytes
+	r.ev.IPAllowlist = r.IPAllowlist
+	r.registry = r.Registry
+	if r.registry == nil {
+		r.registry = events.DefaultRegistry
+	}
+	r.ev.Registry = r.registry
+	r.ev.UnknownAsRaw = r.acceptsUnknown()
+	dispatch := r.dispatcher()
+	if len(r.Environments) > 0 {
+		dispatch = r.environmentDispatcher(dispatch)
+	}
+	if r.queue == nil {
+		dispatch = r.withInterceptors(dispatch)
+	}
+	if r.Deduplicator != nil {
+		dispatch = deduplicate(r.Deduplicator, dispatch)
+	}
+	if r.Freshness != nil && r.queue != nil {
+		dispatch = forgetFailed(r.Freshness, dispatch)
+	}
+	if r.queue != nil {
+		queued := dispatch
+		dispatch = r.withInterceptors(func(ev events.Event, rw http.ResponseWriter, req *http.Request) {
+			r.queue.enqueue(ev, rw, req, queued)
+		})
+	}
+	getEvent := r.ev.EventFromRequestWithEnvironment()
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ev, env, err := getEvent(req)
+		if err != nil {
+			r.writeError(rw, req, err)
+			return
+		}
+		if r.Freshness != nil {
+			sw := response.NewWriter(rw)
+			defer func() {
+				if sw.Failed() {
+					r.Freshness.Forget(ev)
+					return
+				}
+				r.Freshness.Accept(ev)
+			}()
+			rw = sw
+		}
+		switch r.EnvironmentPolicies[env] {
+		case EnvironmentReject:
+			r.writeError(rw, req, httperrors.NewForbiddenError(fmt.Sprintf("events from environment %q are not accepted", env)))
+			return
+		case EnvironmentAcknowledge:
+			rw.WriteHeader(http.StatusOK)
+			return
+		}
+		if env != "" {
+			req = req.WithContext(events.WithEnvironment(req.Context(), env))
+		}
+		if r.ErrorLog != nil {
+			req = req.WithContext(context.WithValue(req.Context(), errorLogKey{}, r.ErrorLog))
+		}
+		if r.ErrorHandler != nil {
+			req = req.WithContext(context.WithValue(req.Context(), errorHandlerKey{}, r.ErrorHandler))
+		}
+		dispatch(ev, rw, req)
 	})
 }
 
+// NewRouter creates router from c. If c.Workers is positive, it also
+// starts workers processing events asynchronously, they are stopped
+// by Shutdown.
 func NewRouter(c Config) Router {
-	return Router{Config: c}
+	r := Router{Config: c}
+	if c.Workers > 0 {
+		r.queue = newQueue(c.Workers, c.QueueSize)
+	}
+	return r
 }
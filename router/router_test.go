@@ -2,15 +2,27 @@ package router
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/dennor/go-paddle/events"
 	"github.com/dennor/go-paddle/events/alerts"
 	"github.com/dennor/go-paddle/events/subscription"
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/journal"
+	"github.com/dennor/go-paddle/middleware"
 	"github.com/dennor/go-paddle/mime"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockAlertHighRiskTransactionCreated struct {
@@ -427,6 +439,83 @@ func TestRouter(t *testing.T) {
 	})
 }
 
+type customEvent struct {
+	AlertName string `json:"alert_name"`
+}
+
+func (c *customEvent) Serialize() ([]byte, error) { return nil, nil }
+func (c *customEvent) Signature() ([]byte, error) { return nil, nil }
+func (c *customEvent) GetAlertName() string       { return c.AlertName }
+func (c *customEvent) GetAlertID() (int, bool)    { return 0, false }
+func (c *customEvent) GetEventTime() time.Time    { return time.Time{} }
+func (c *customEvent) GetPassthrough() string     { return "" }
+func (c *customEvent) GetEmail() string           { return "" }
+func (c *customEvent) GetCheckoutID() string      { return "" }
+
+func TestRouterRegistry(t *testing.T) {
+	registry := events.DefaultRegistry.Clone()
+	registry.Register("custom_event", func() events.Event { return new(customEvent) })
+	newReq := func(query string) *http.Request {
+		req := &http.Request{
+			Header: make(http.Header),
+		}
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		req.Body = ioutil.NopCloser(bytes.NewReader([]byte(query)))
+		return req
+	}
+
+	t.Run("CallsHandlerForRegisteredEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		var got events.Event
+		handler := NewRouter(Config{
+			Registry: registry,
+			Handlers: map[string]EventHandler{
+				"custom_event": EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+					got = e
+				}),
+			},
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=custom_event"))
+		assert.Equal(http.StatusOK, rw.Code)
+		assert.Equal(&customEvent{AlertName: "custom_event"}, got)
+	})
+
+	t.Run("MissingHandler", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		NewRouter(Config{Registry: registry}).Handler().ServeHTTP(rw, newReq("alert_name=custom_event"))
+		assert.Equal(http.StatusNotFound, rw.Code)
+	})
+
+	t.Run("CallsRawForUnknownEvent", func(t *testing.T) {
+		assert := assert.New(t)
+		var got *events.Raw
+		handler := NewRouter(Config{
+			Raw: RawFunc(func(e *events.Raw, rw http.ResponseWriter, req *http.Request) {
+				got = e
+			}),
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=brand_new_alert&alert_id=7"))
+		assert.Equal(http.StatusOK, rw.Code)
+		assert.Equal(&events.Raw{
+			AlertName: "brand_new_alert",
+			Fields: events.Fields{
+				"alert_name": "brand_new_alert",
+				"alert_id":   "7",
+			},
+		}, got)
+	})
+
+	t.Run("RejectsUnknownEventWithoutRaw", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		NewRouter(Config{}).Handler().ServeHTTP(rw, newReq("alert_name=brand_new_alert"))
+		assert.Equal(http.StatusBadRequest, rw.Code)
+	})
+}
+
 type benchAlertHighRiskTransactionCreated struct{}
 
 func (*benchAlertHighRiskTransactionCreated) ServeHTTP(e *alerts.HighRiskTransactionCreated, rw http.ResponseWriter, req *http.Request) {
@@ -710,3 +799,416 @@ func BenchmarkRouter(b *testing.B) {
 		})
 	}
 }
+
+func TestRouterUnhandled(t *testing.T) {
+	newReq := func(query string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(query)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		assert := assert.New(t)
+		handler := NewRouter(Config{}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=subscription_created"))
+		assert.Equal(http.StatusNotFound, rw.Code)
+		assert.Contains(rw.Body.String(), "subscription_created")
+		rw = httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=brand_new_alert"))
+		assert.Equal(http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("Acknowledge", func(t *testing.T) {
+		assert := assert.New(t)
+		handler := NewRouter(Config{Unhandled: UnhandledAcknowledge}).Handler()
+		for _, q := range []string{"alert_name=subscription_created", "alert_name=brand_new_alert"} {
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newReq(q))
+			assert.Equal(http.StatusOK, rw.Code, q)
+		}
+	})
+
+	t.Run("CatchAll", func(t *testing.T) {
+		assert := assert.New(t)
+		var got []events.Event
+		handler := NewRouter(Config{
+			Unhandled: UnhandledCatchAll,
+			CatchAll: EventHandlerFunc(func(e events.Event, rw http.ResponseWriter, req *http.Request) {
+				got = append(got, e)
+				rw.WriteHeader(http.StatusAccepted)
+			}),
+			SubscriptionUpdated: SubscriptionUpdatedFunc(func(e *subscription.Updated, rw http.ResponseWriter, req *http.Request) {}),
+		}).Handler()
+		for _, q := range []string{"alert_name=subscription_created", "alert_name=brand_new_alert", "alert_name=subscription_updated"} {
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newReq(q))
+			if q == "alert_name=subscription_updated" {
+				assert.Equal(http.StatusOK, rw.Code, q)
+			} else {
+				assert.Equal(http.StatusAccepted, rw.Code, q)
+			}
+		}
+		assert.Equal([]events.Event{
+			&subscription.Created{AlertName: "subscription_created"},
+			&events.Raw{AlertName: "brand_new_alert", Fields: events.Fields{"alert_name": "brand_new_alert"}},
+		}, got)
+	})
+
+	t.Run("CatchAllWithoutHandler", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		NewRouter(Config{Unhandled: UnhandledCatchAll}).Handler().ServeHTTP(rw, newReq("alert_name=subscription_created"))
+		assert.Equal(http.StatusNotFound, rw.Code)
+	})
+
+	t.Run("MissingHandlers", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Len(Config{}.MissingHandlers(), 17)
+		missing := Config{
+			AlertTransferPaid:   AlertTransferPaidFunc(func(e *alerts.TransferPaid, rw http.ResponseWriter, req *http.Request) {}),
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {}),
+		}.MissingHandlers()
+		assert.Len(missing, 15)
+		assert.NotContains(missing, alerts.TransferPaidAlertName)
+		assert.NotContains(missing, subscription.CreatedAlertName)
+		assert.Contains(missing, subscription.UpdatedAlertName)
+	})
+}
+
+func TestRouterJournal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	j, err := journal.Open(dir, journal.Options{})
+	require.NoError(err)
+	defer j.Close()
+	var got []*subscription.Created
+	handler := NewRouter(Config{
+		Journal: j,
+		SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+			got = append(got, e)
+		}),
+	}).Handler()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created&alert_id=4")))
+	req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Equal(http.StatusOK, rw.Code)
+	assert.Equal(int64(1), j.NextOffset())
+
+	next, err := j.Replay(context.Background(), 0, handler)
+	assert.NoError(err)
+	assert.Equal(int64(1), next)
+	assert.Equal(int64(1), j.NextOffset(), "replayed alert must not be journaled again")
+	require.Len(got, 2)
+	assert.Equal(got[0], got[1])
+}
+
+// passthroughEnvironment accepts every event and reports its passthrough
+// as environment.
+type passthroughEnvironment struct{}
+
+func (passthroughEnvironment) Verify(e events.Event) error { return nil }
+
+func (passthroughEnvironment) VerifyEnvironment(e events.Event) (events.Environment, error) {
+	return events.Environment(e.GetPassthrough()), nil
+}
+
+func TestRouterEnvironments(t *testing.T) {
+	newReq := func(env events.Environment) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created&passthrough="+string(env))))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+	created := func(name string, got *[]string) SubscriptionCreated {
+		return SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+			env, _ := events.EnvironmentFromContext(req.Context())
+			*got = append(*got, name+":"+string(env))
+		})
+	}
+
+	t.Run("Handlers", func(t *testing.T) {
+		assert := assert.New(t)
+		var got []string
+		handler := NewRouter(Config{
+			Verifier:            passthroughEnvironment{},
+			SubscriptionCreated: created("default", &got),
+			Environments: map[events.Environment]Config{
+				events.EnvironmentSandbox: {SubscriptionCreated: created("sandbox", &got)},
+			},
+		}).Handler()
+		for _, env := range []events.Environment{events.EnvironmentProduction, events.EnvironmentSandbox, ""} {
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, newReq(env))
+			assert.Equal(http.StatusOK, rw.Code, env)
+		}
+		assert.Equal([]string{"default:production", "sandbox:sandbox", "default:"}, got)
+	})
+
+	t.Run("EnvironmentWithoutHandler", func(t *testing.T) {
+		assert := assert.New(t)
+		var got []string
+		handler := NewRouter(Config{
+			Verifier:            passthroughEnvironment{},
+			SubscriptionCreated: created("default", &got),
+			Environments: map[events.Environment]Config{
+				events.EnvironmentSandbox: {Unhandled: UnhandledAcknowledge},
+			},
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq(events.EnvironmentSandbox))
+		assert.Equal(http.StatusOK, rw.Code)
+		assert.Empty(got, "sandbox event must not reach production handler")
+	})
+
+	t.Run("Policies", func(t *testing.T) {
+		assert := assert.New(t)
+		var got []string
+		handler := NewRouter(Config{
+			Verifier:            passthroughEnvironment{},
+			SubscriptionCreated: created("default", &got),
+			EnvironmentPolicies: map[events.Environment]EnvironmentPolicy{
+				events.EnvironmentProduction: EnvironmentReject,
+				events.EnvironmentSandbox:    EnvironmentAcknowledge,
+			},
+		}).Handler()
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq(events.EnvironmentProduction))
+		assert.Equal(http.StatusForbidden, rw.Code)
+		rw = httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq(events.EnvironmentSandbox))
+		assert.Equal(http.StatusOK, rw.Code)
+		assert.Empty(got)
+	})
+}
+
+func TestRouterFreshness(t *testing.T) {
+	body := test.Sign(map[string]string{
+		"alert_name": "transfer_paid",
+		"amount":     "1.23",
+		"currency":   "PLN",
+		"event_time": "2019-04-15 07:37:53",
+		"payout_id":  "2",
+		"status":     "closed",
+	}).URL
+	newHandler := func(c Config, status *int, calls *int) http.Handler {
+		c.Verifier = events.RSAVerifier{PublicKey: &test.Key.PublicKey}
+		c.AlertTransferPaid = AlertTransferPaidFunc(func(e *alerts.TransferPaid, rw http.ResponseWriter, req *http.Request) {
+			*calls++
+			rw.WriteHeader(*status)
+		})
+		return NewRouter(c).Handler()
+	}
+	serve := func(handler http.Handler) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		return rw
+	}
+
+	t.Run("RetriesFailed", func(t *testing.T) {
+		assert := assert.New(t)
+		status, calls := http.StatusInternalServerError, 0
+		handler := newHandler(Config{Freshness: middleware.NewFreshness(0, 10)}, &status, &calls)
+		assert.Equal(http.StatusInternalServerError, serve(handler).Code)
+		status = http.StatusOK
+		assert.Equal(http.StatusOK, serve(handler).Code, "failed alert must be retried")
+		assert.Equal(http.StatusOK, serve(handler).Code, "redelivery must be acknowledged")
+		assert.Equal(2, calls)
+	})
+
+	t.Run("ConcurrentRedelivery", func(t *testing.T) {
+		assert := assert.New(t)
+		started, release := make(chan struct{}), make(chan struct{})
+		var calls int32
+		handler := NewRouter(Config{
+			Verifier:  events.RSAVerifier{PublicKey: &test.Key.PublicKey},
+			Freshness: middleware.NewFreshness(0, 10),
+			AlertTransferPaid: AlertTransferPaidFunc(func(e *alerts.TransferPaid, rw http.ResponseWriter, req *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					close(started)
+					<-release
+					rw.WriteHeader(http.StatusInternalServerError)
+				}
+			}),
+		}).Handler()
+		first := make(chan int)
+		go func() { first <- serve(handler).Code }()
+		<-started
+		assert.Equal(http.StatusConflict, serve(handler).Code, "redelivery of alert in flight must be retried")
+		close(release)
+		assert.Equal(http.StatusInternalServerError, <-first)
+		assert.Equal(http.StatusOK, serve(handler).Code, "retry of failed alert must reach handler")
+		assert.Equal(int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("WithDeduplicator", func(t *testing.T) {
+		assert := assert.New(t)
+		status, calls := http.StatusOK, 0
+		freshness := middleware.NewFreshness(0, 10)
+		handler := newHandler(Config{
+			Freshness:    freshness,
+			Deduplicator: NewMemoryDeduplicator(time.Hour),
+			ErrorHandler: middleware.WriteProblem,
+		}, &status, &calls)
+		assert.Equal(http.StatusOK, serve(handler).Code)
+		rw := serve(handler)
+		assert.Equal(http.StatusOK, rw.Code, "redelivery of handled alert must be acknowledged")
+		assert.Empty(rw.Body.String())
+		// once signature drops out of freshness cache deduplicator still acknowledges
+		fields, err := events.FieldsFromForm([]byte(body))
+		require.NoError(t, err)
+		freshness.Forget(fields)
+		assert.Equal(http.StatusOK, serve(handler).Code)
+		assert.Equal(1, calls, "handled alert must not reach handler again")
+	})
+
+	t.Run("ReplayStatus", func(t *testing.T) {
+		assert := assert.New(t)
+		status, calls := http.StatusOK, 0
+		freshness := middleware.NewFreshness(0, 10)
+		freshness.ReplayStatus = http.StatusConflict
+		handler := newHandler(Config{Freshness: freshness, ErrorHandler: middleware.WriteProblem}, &status, &calls)
+		assert.Equal(http.StatusOK, serve(handler).Code)
+		rw := serve(handler)
+		assert.Equal(http.StatusConflict, rw.Code)
+		assert.Equal(mime.ApplicationProblemJSON, rw.Header().Get(mime.ContentTypeHeader))
+		assert.Equal(1, calls)
+	})
+}
+
+func TestRouterMaxBodyBytes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created")))
+	req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+	rw := httptest.NewRecorder()
+	NewRouter(Config{MaxBodyBytes: 10}).Handler().ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+}
+
+func TestRouterIPAllowlist(t *testing.T) {
+	allowlist, err := middleware.NewIPAllowlist(middleware.PaddleProductionIPs, nil)
+	require.NoError(t, err)
+	handler := NewRouter(Config{
+		IPAllowlist:         allowlist,
+		SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {}),
+	}).Handler()
+	for remote, status := range map[string]int{
+		"34.232.58.13:443": http.StatusOK,
+		"1.2.3.4:443":      http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created")))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		req.RemoteAddr = remote
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		assert.Equal(t, status, rw.Code, remote)
+	}
+}
+
+func TestRouterIPAllowlistReplay(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	j, err := journal.Open(dir, journal.Options{})
+	require.NoError(err)
+	defer j.Close()
+	allowlist, err := middleware.NewIPAllowlist(middleware.PaddleProductionIPs, nil)
+	require.NoError(err)
+	var got int
+	handler := NewRouter(Config{
+		Journal:     j,
+		IPAllowlist: allowlist,
+		SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {
+			got++
+		}),
+	}).Handler()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("alert_name=subscription_created&alert_id=4")))
+	req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+	req.RemoteAddr = "34.232.58.13:443"
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	require.Equal(http.StatusOK, rw.Code)
+
+	next, err := j.Replay(context.Background(), 0, handler)
+	assert.NoError(err)
+	assert.Equal(int64(1), next)
+	assert.Equal(2, got)
+}
+
+func TestRouterErrorHandler(t *testing.T) {
+	newReq := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+		return req
+	}
+	handler := NewRouter(Config{
+		ErrorHandler: middleware.WriteProblem,
+		SubscriptionCreated: SubscriptionCreatedContextFunc(func(ctx context.Context, e *subscription.Created) error {
+			return errors.New("database is down")
+		}),
+	}).Handler()
+
+	t.Run("Rejected", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=brand_new_alert"))
+		assert.Equal(http.StatusBadRequest, rw.Code)
+		assert.Equal(mime.ApplicationProblemJSON, rw.Header().Get(mime.ContentTypeHeader))
+		assert.JSONEq(`{"type":"about:blank","title":"Bad Request","status":400,"detail":"unknown alert","alert_name":"brand_new_alert"}`, rw.Body.String())
+	})
+
+	t.Run("HandlerFailed", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=subscription_created"))
+		assert.Equal(http.StatusInternalServerError, rw.Code)
+		assert.JSONEq(`{"type":"about:blank","title":"Internal Server Error","status":500}`, rw.Body.String())
+	})
+
+	t.Run("Unhandled", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, newReq("alert_name=subscription_updated"))
+		assert.Equal(http.StatusNotFound, rw.Code)
+		assert.JSONEq(`{"type":"about:blank","title":"Not Found","status":404}`, rw.Body.String())
+	})
+
+	t.Run("DeduplicationFailed", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := httptest.NewRecorder()
+		NewRouter(Config{
+			ErrorHandler:        middleware.WriteProblem,
+			Deduplicator:        failingDeduplicator{},
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {}),
+		}).Handler().ServeHTTP(rw, newReq("alert_name=subscription_created&alert_id=4"))
+		assert.Equal(http.StatusInternalServerError, rw.Code)
+		assert.JSONEq(`{"type":"about:blank","title":"Internal Server Error","status":500}`, rw.Body.String())
+	})
+
+	t.Run("QueueClosed", func(t *testing.T) {
+		assert := assert.New(t)
+		r := NewRouter(Config{
+			ErrorHandler:        middleware.WriteProblem,
+			Workers:             1,
+			SubscriptionCreated: SubscriptionCreatedFunc(func(e *subscription.Created, rw http.ResponseWriter, req *http.Request) {}),
+		})
+		assert.NoError(r.Shutdown(context.Background()))
+		rw := httptest.NewRecorder()
+		r.Handler().ServeHTTP(rw, newReq("alert_name=subscription_created"))
+		assert.Equal(http.StatusServiceUnavailable, rw.Code)
+		assert.JSONEq(`{"type":"about:blank","title":"Service Unavailable","status":503}`, rw.Body.String())
+	})
+}
+
+// failingDeduplicator fails every lookup.
+type failingDeduplicator struct{}
+
+func (failingDeduplicator) Seen(key string) (bool, error) { return false, errors.New("disk is full") }
+
+func (failingDeduplicator) MarkSeen(key string) error { return errors.New("disk is full") }
@@ -7,3 +7,7 @@ type VerificationError struct {
 func NewVerificationError(err error) VerificationError {
 	return VerificationError{err}
 }
+
+func (v VerificationError) Unwrap() error {
+	return v.error
+}
@@ -0,0 +1,121 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderName is the header carrying paddle billing signature.
+const HeaderName = "Paddle-Signature"
+
+// DefaultTolerance is the maximum allowed difference between signature
+// timestamp and current time if HMAC.Tolerance is not set.
+const DefaultTolerance = 5 * time.Second
+
+// HMAC verifies paddle billing notifications signed with
+// Paddle-Signature: ts=<unix time>;h1=<hex hmac-sha256> header.
+type HMAC struct {
+	// Secret is the endpoint secret key from paddle dashboard.
+	Secret []byte
+	// Tolerance is the maximum allowed age of signature timestamp.
+	// Zero means DefaultTolerance, negative disables the check.
+	Tolerance time.Duration
+	// Now returns current time, time.Now is used if nil.
+	Now func() time.Time
+}
+
+func (h HMAC) tolerance() time.Duration {
+	if h.Tolerance == 0 {
+		return DefaultTolerance
+	}
+	return h.Tolerance
+}
+
+func (h HMAC) now() time.Time {
+	if h.Now == nil {
+		return time.Now()
+	}
+	return h.Now()
+}
+
+type signatureHeader struct {
+	ts     string
+	hashes [][]byte
+}
+
+func parseSignatureHeader(header string) (signatureHeader, error) {
+	var sh signatureHeader
+	for _, part := range strings.Split(header, ";") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "ts":
+			sh.ts = kv[1]
+		case "h1":
+			b, err := hex.DecodeString(kv[1])
+			if err != nil {
+				return sh, err
+			}
+			sh.hashes = append(sh.hashes, b)
+		}
+	}
+	if sh.ts == "" {
+		return sh, errors.New("missing signature timestamp")
+	}
+	if len(sh.hashes) == 0 {
+		return sh, errors.New("missing h1 signature")
+	}
+	return sh, nil
+}
+
+func (h HMAC) sign(ts string, body []byte) []byte {
+	mac := hmac.New(sha256.New, h.Secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte{':'})
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// Sign returns Paddle-Signature header value for body at time t.
+func (h HMAC) Sign(body []byte, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "ts=" + ts + ";h1=" + hex.EncodeToString(h.sign(ts, body))
+}
+
+// Verify checks that header is a valid Paddle-Signature for body.
+func (h HMAC) Verify(body []byte, header string) error {
+	if len(h.Secret) == 0 {
+		return NewVerificationError(errors.New("empty secret"))
+	}
+	sh, err := parseSignatureHeader(header)
+	if err != nil {
+		return NewVerificationError(err)
+	}
+	ts, err := strconv.ParseInt(sh.ts, 10, 64)
+	if err != nil {
+		return NewVerificationError(err)
+	}
+	if tolerance := h.tolerance(); tolerance > 0 {
+		diff := h.now().Sub(time.Unix(ts, 0))
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			return NewVerificationError(errors.New("signature timestamp outside of tolerance"))
+		}
+	}
+	expected := h.sign(sh.ts, body)
+	for _, sig := range sh.hashes {
+		if hmac.Equal(expected, sig) {
+			return nil
+		}
+	}
+	return NewVerificationError(errors.New("signature mismatch"))
+}
@@ -0,0 +1,67 @@
+package signature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMAC(t *testing.T) {
+	body := []byte(`{"event_id":"evt_01","event_type":"transaction.completed"}`)
+	now := time.Unix(1671552777, 0)
+	h := HMAC{
+		Secret: []byte("pdl_ntfset_secret"),
+		Now:    func() time.Time { return now },
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.NoError(h.Verify(body, h.Sign(body, now)))
+	})
+
+	t.Run("MultipleSignatures", func(t *testing.T) {
+		assert := assert.New(t)
+		old := HMAC{Secret: []byte("old secret")}
+		header := old.Sign(body, now) + ";" + h.Sign(body, now)[len("ts=1671552777;"):]
+		assert.NoError(h.Verify(body, header))
+	})
+
+	t.Run("TamperedBody", func(t *testing.T) {
+		assert := assert.New(t)
+		err := h.Verify([]byte(`{}`), h.Sign(body, now))
+		assert.Error(err)
+		assert.IsType(VerificationError{}, err)
+	})
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		assert := assert.New(t)
+		other := HMAC{Secret: []byte("other")}
+		assert.Error(h.Verify(body, other.Sign(body, now)))
+	})
+
+	t.Run("Tolerance", func(t *testing.T) {
+		assert := assert.New(t)
+		header := h.Sign(body, now.Add(-DefaultTolerance-time.Second))
+		assert.Error(h.Verify(body, header))
+		relaxed := h
+		relaxed.Tolerance = time.Minute
+		assert.NoError(relaxed.Verify(body, header))
+		disabled := h
+		disabled.Tolerance = -1
+		assert.NoError(disabled.Verify(body, h.Sign(body, now.Add(-24*time.Hour))))
+	})
+
+	t.Run("MalformedHeader", func(t *testing.T) {
+		assert := assert.New(t)
+		for _, header := range []string{
+			"",
+			"ts=1671552777",
+			"h1=abcd",
+			"ts=now;h1=abcd",
+			"ts=1671552777;h1=not-hex",
+		} {
+			assert.Error(h.Verify(body, header), "header was %s", header)
+		}
+	})
+}
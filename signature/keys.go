@@ -0,0 +1,74 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+)
+
+var (
+	pemBegin = []byte("-----BEGIN")
+	pemEnd   = []byte("-----END")
+	pemDash  = []byte("-----")
+)
+
+// ParsePublicKeyPEM parses RSA public key as shown in paddle dashboard,
+// "-----BEGIN PUBLIC KEY-----" followed by base64 encoded key. Key may
+// have lost its line breaks or have them escaped as \n, which happens
+// when it is kept in environment variable, base64 without header is
+// accepted as well. Both PKIX and PKCS #1 keys are supported.
+func ParsePublicKeyPEM(b []byte) (*rsa.PublicKey, error) {
+	b = bytes.TrimSpace(b)
+	if bytes.HasPrefix(b, pemBegin) {
+		// skip "-----BEGIN PUBLIC KEY-----"
+		i := bytes.Index(b[len(pemBegin):], pemDash)
+		if i < 0 {
+			return nil, errors.New("invalid PEM header")
+		}
+		b = b[len(pemBegin)+i+len(pemDash):]
+		end := bytes.Index(b, pemEnd)
+		if end < 0 {
+			return nil, errors.New("missing PEM footer")
+		}
+		b = b[:end]
+	}
+	b = bytes.Replace(b, []byte(`\n`), nil, -1)
+	b = bytes.Join(bytes.Fields(b), nil)
+	der := make([]byte, base64.StdEncoding.DecodedLen(len(b)))
+	n, err := base64.StdEncoding.Decode(der, b)
+	if err != nil {
+		return nil, err
+	}
+	der = der[:n]
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("not an RSA public key")
+		}
+		return rsaKey, nil
+	}
+	return x509.ParsePKCS1PublicKey(der)
+}
+
+// LoadPublicKeyFile reads public key from file with ParsePublicKeyPEM.
+func LoadPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublicKeyPEM(b)
+}
+
+// LoadPublicKeyEnv reads public key from environment variable name
+// with ParsePublicKeyPEM.
+func LoadPublicKeyEnv(name string) (*rsa.PublicKey, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok || v == "" {
+		return nil, errors.New("environment variable " + name + " is not set")
+	}
+	return ParsePublicKeyPEM([]byte(v))
+}
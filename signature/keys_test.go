@@ -0,0 +1,78 @@
+package signature
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePublicKeyPEM(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(cryptoReader{}, 1024)
+	require.NoError(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	pkcs1 := x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))
+	data := []struct {
+		name string
+		key  string
+	}{
+		{"PEM", pemKey},
+		{"PKCS1", string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: pkcs1}))},
+		{"SingleLine", strings.Replace(pemKey, "\n", " ", -1)},
+		{"EscapedNewLines", strings.Replace(pemKey, "\n", `\n`, -1)},
+		{"Indented", "\n\t" + strings.Replace(pemKey, "\n", "\n\t", -1)},
+		{"Base64", base64.StdEncoding.EncodeToString(pkix)},
+	}
+	for _, tt := range data {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			key, err := ParsePublicKeyPEM([]byte(tt.key))
+			assert.NoError(err)
+			assert.Equal(&privateKey.PublicKey, key)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := ParsePublicKeyPEM([]byte("-----BEGIN PUBLIC KEY-----\nAAAA"))
+		assert.Error(err)
+		_, err = ParsePublicKeyPEM([]byte("not a key"))
+		assert.Error(err)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		assert := assert.New(t)
+		dir, err := ioutil.TempDir("", "keys")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "paddle.pem")
+		require.NoError(t, ioutil.WriteFile(path, []byte(pemKey), 0600))
+		key, err := LoadPublicKeyFile(path)
+		assert.NoError(err)
+		assert.Equal(&privateKey.PublicKey, key)
+		_, err = LoadPublicKeyFile(filepath.Join(dir, "missing.pem"))
+		assert.Error(err)
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		assert := assert.New(t)
+		const name = "GO_PADDLE_TEST_PUBLIC_KEY"
+		os.Setenv(name, strings.Replace(pemKey, "\n", `\n`, -1))
+		defer os.Unsetenv(name)
+		key, err := LoadPublicKeyEnv(name)
+		assert.NoError(err)
+		assert.Equal(&privateKey.PublicKey, key)
+		_, err = LoadPublicKeyEnv(name + "_MISSING")
+		assert.Error(err)
+	})
+}
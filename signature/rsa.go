@@ -4,17 +4,27 @@ import (
 	"crypto"
 	"crypto/rsa"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"hash"
 )
 
+// ErrUnsupportedHash is returned when RSA.Hash is not one of SHA-1,
+// SHA-224, SHA-256, SHA-384 or SHA-512.
+var ErrUnsupportedHash = errors.New("unsupported hash")
+
 // RSA verifies RSA signatures with user defined hashing
 // If hashing func is not provided, sha1 is used by default.
+// Signatures are PKCS #1 v1.5 unless PSS is set, then they are
+// verified as RSA-PSS with given options.
 type RSA struct {
 	PublicKey *rsa.PublicKey
 	Encoding  *base64.Encoding
 	Hash      crypto.Hash
+	PSS       *rsa.PSSOptions
 }
 
 func (r RSA) hash() crypto.Hash {
@@ -27,10 +37,17 @@ func (r RSA) hash() crypto.Hash {
 func (r RSA) hashFunc() hash.Hash {
 	switch r.hash() {
 	case crypto.SHA1:
-		fallthrough
-	default:
 		return sha1.New()
+	case crypto.SHA224:
+		return sha256.New224()
+	case crypto.SHA256:
+		return sha256.New()
+	case crypto.SHA384:
+		return sha512.New384()
+	case crypto.SHA512:
+		return sha512.New()
 	}
+	return nil
 }
 
 func (r RSA) encoding() *base64.Encoding {
@@ -52,7 +69,7 @@ func (r RSA) getSignature(b64sig []byte) ([]byte, error) {
 func (r RSA) hashData(data []byte) ([]byte, error) {
 	h := r.hashFunc()
 	if h == nil {
-		return nil, errors.New("unimplemented hash")
+		return nil, fmt.Errorf("%w %v", ErrUnsupportedHash, r.hash())
 	}
 	_, err := h.Write(data)
 	if err != nil {
@@ -70,5 +87,8 @@ func (r RSA) Verify(data, b64signature []byte) error {
 	if err != nil {
 		return err
 	}
+	if r.PSS != nil {
+		return rsa.VerifyPSS(r.PublicKey, r.hash(), hashed, sig, r.PSS)
+	}
 	return rsa.VerifyPKCS1v15(r.PublicKey, r.hash(), hashed, sig)
 }
@@ -6,6 +6,7 @@ import (
 	"crypto/rsa"
 	"crypto/sha1"
 	"encoding/base64"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,3 +43,48 @@ func TestRSASHA1(t *testing.T) {
 	}
 	assert.NoError(rsasha1.Verify(message, b64sig))
 }
+
+func TestRSAHashes(t *testing.T) {
+	reader := cryptoReader{}
+	privateKey, err := rsa.GenerateKey(reader, 1024)
+	require.NoError(t, err)
+	message := []byte("test message")
+	hashes := []crypto.Hash{crypto.SHA1, crypto.SHA224, crypto.SHA256, crypto.SHA384, crypto.SHA512}
+	sign := func(t *testing.T, h crypto.Hash, pss bool) []byte {
+		hasher := h.New()
+		hasher.Write(message)
+		hashed := hasher.Sum(nil)
+		var sig []byte
+		var err error
+		if pss {
+			sig, err = rsa.SignPSS(reader, privateKey, h, hashed, nil)
+		} else {
+			sig, err = rsa.SignPKCS1v15(reader, privateKey, h, hashed)
+		}
+		require.NoError(t, err)
+		return []byte(base64.StdEncoding.EncodeToString(sig))
+	}
+	for _, h := range hashes {
+		t.Run(h.String(), func(t *testing.T) {
+			assert := assert.New(t)
+			pkcs := RSA{PublicKey: &privateKey.PublicKey, Hash: h}
+			pss := RSA{PublicKey: &privateKey.PublicKey, Hash: h, PSS: &rsa.PSSOptions{}}
+			assert.NoError(pkcs.Verify(message, sign(t, h, false)))
+			assert.NoError(pss.Verify(message, sign(t, h, true)))
+			assert.Error(pkcs.Verify(message, sign(t, h, true)))
+			assert.Error(pss.Verify(message, sign(t, h, false)))
+			assert.Error(pkcs.Verify([]byte("other message"), sign(t, h, false)))
+		})
+	}
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		assert.Error(t, RSA{PublicKey: &privateKey.PublicKey, Hash: crypto.SHA256}.Verify(message, sign(t, crypto.SHA512, false)))
+	})
+
+	t.Run("UnsupportedHash", func(t *testing.T) {
+		assert := assert.New(t)
+		err := RSA{PublicKey: &privateKey.PublicKey, Hash: crypto.MD5}.Verify(message, sign(t, crypto.SHA1, false))
+		assert.True(errors.Is(err, ErrUnsupportedHash))
+		assert.Contains(err.Error(), "MD5")
+	})
+}
@@ -0,0 +1,363 @@
+package sim
+
+import (
+	"sort"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/alerts"
+	"github.com/dennor/go-paddle/events/subscription"
+)
+
+const (
+	defaultEventTime  = "2019-04-15 07:37:53"
+	defaultDate       = "2019-05-15"
+	defaultCheckoutID = "1-c8a82616c183ad6-377f00add1"
+	defaultEmail      = "jan@kowalski.net"
+)
+
+// defaults holds fields of every supported alert with realistic values.
+var defaults = map[string]events.Fields{
+	alerts.HighRiskTransactionCreatedAlertName: {
+		"alert_id":               "1730031676",
+		"case_id":                "123",
+		"checkout_id":            defaultCheckoutID,
+		"created_at":             defaultEventTime,
+		"customer_email_address": defaultEmail,
+		"customer_user_id":       "1234",
+		"event_time":             defaultEventTime,
+		"marketing_consent":      "1",
+		"passthrough":            "Example String",
+		"product_id":             "12345",
+		"risk_score":             "66.6",
+		"status":                 "pending",
+	},
+	alerts.HighRiskTransactionUpdatedAlertName: {
+		"alert_id":               "1730031677",
+		"case_id":                "123",
+		"checkout_id":            defaultCheckoutID,
+		"created_at":             defaultEventTime,
+		"customer_email_address": defaultEmail,
+		"customer_user_id":       "1234",
+		"event_time":             defaultEventTime,
+		"marketing_consent":      "1",
+		"passthrough":            "Example String",
+		"product_id":             "12345",
+		"risk_score":             "66.6",
+		"status":                 "accepted",
+	},
+	alerts.LockerProcessedAlertName: {
+		"alert_id":          "1730031678",
+		"checkout_id":       defaultCheckoutID,
+		"checkout_recovery": "0",
+		"coupon":            "secret-coupon-code",
+		"download":          "https://example.org/download",
+		"email":             defaultEmail,
+		"event_time":        defaultEventTime,
+		"instructions":      "Thank you for your purchase",
+		"license":           "ABCD-EFGH-IJKL-MNOP",
+		"marketing_consent": "1",
+		"order_id":          "123456",
+		"product_id":        "12345",
+		"quantity":          "1",
+	},
+	alerts.NewAudienceMemberAlertName: {
+		"alert_id":          "1730031679",
+		"created_at":        defaultEventTime,
+		"email":             defaultEmail,
+		"event_time":        defaultEventTime,
+		"marketing_consent": "1",
+		"products":          "12345,678910",
+		"source":            "Checkout",
+		"subscribed":        "1",
+		"user_id":           "1234",
+	},
+	alerts.PaymentDisputeClosedAlertName: {
+		"alert_id":          "1730031680",
+		"amount":            "49.99",
+		"checkout_id":       defaultCheckoutID,
+		"currency":          "USD",
+		"email":             defaultEmail,
+		"event_time":        defaultEventTime,
+		"fee_usd":           "15",
+		"marketing_consent": "1",
+		"order_id":          "123456",
+		"passthrough":       "Example String",
+		"status":            "closed",
+	},
+	alerts.PaymentDisputeCreatedAlertName: {
+		"alert_id":          "1730031681",
+		"amount":            "49.99",
+		"checkout_id":       defaultCheckoutID,
+		"currency":          "USD",
+		"email":             defaultEmail,
+		"event_time":        defaultEventTime,
+		"fee_usd":           "15",
+		"marketing_consent": "1",
+		"order_id":          "123456",
+		"passthrough":       "Example String",
+		"status":            "pending",
+	},
+	alerts.PaymentRefundedAlertName: {
+		"alert_id":                  "1730031682",
+		"amount":                    "49.99",
+		"balance_currency":          "USD",
+		"balance_earnings_decrease": "42.49",
+		"balance_fee_refund":        "2.5",
+		"balance_gross_refund":      "49.99",
+		"balance_tax_refund":        "5",
+		"checkout_id":               defaultCheckoutID,
+		"currency":                  "USD",
+		"earnings_decrease":         "42.49",
+		"email":                     defaultEmail,
+		"event_time":                defaultEventTime,
+		"fee_refund":                "2.5",
+		"gross_refund":              "49.99",
+		"marketing_consent":         "1",
+		"order_id":                  "123456",
+		"passthrough":               "Example String",
+		"quantity":                  "1",
+		"refund_type":               "full",
+		"tax_refund":                "5",
+	},
+	alerts.PaymentSucceededAlertName: {
+		"alert_id":            "1730031683",
+		"balance_currency":    "USD",
+		"balance_earnings":    "42.49",
+		"balance_fee":         "2.5",
+		"balance_gross":       "49.99",
+		"balance_tax":         "5",
+		"checkout_id":         defaultCheckoutID,
+		"country":             "PL",
+		"coupon":              "secret-coupon-code",
+		"currency":            "USD",
+		"customer_name":       "Jan Kowalski",
+		"earnings":            "42.49",
+		"email":               defaultEmail,
+		"event_time":          defaultEventTime,
+		"fee":                 "2.5",
+		"ip":                  "127.0.0.1",
+		"marketing_consent":   "1",
+		"order_id":            "123456",
+		"passthrough":         "Example String",
+		"payment_method":      "card",
+		"payment_tax":         "5",
+		"product_id":          "12345",
+		"product_name":        "Example Product",
+		"quantity":            "1",
+		"receipt_url":         "https://example.org/receipt",
+		"sale_gross":          "49.99",
+		"used_price_override": "false",
+	},
+	alerts.TransferCreatedAlertName: {
+		"alert_id":   "1730031684",
+		"amount":     "1234.56",
+		"currency":   "USD",
+		"event_time": defaultEventTime,
+		"payout_id":  "2",
+		"status":     "unpaid",
+	},
+	alerts.TransferPaidAlertName: {
+		"alert_id":   "1730031685",
+		"amount":     "1234.56",
+		"currency":   "USD",
+		"event_time": defaultEventTime,
+		"payout_id":  "2",
+		"status":     "paid",
+	},
+	alerts.UpdateAudienceMemberAlertName: {
+		"alert_id":              "1730031686",
+		"event_time":            defaultEventTime,
+		"new_customer_email":    "jan.kowalski@example.org",
+		"new_marketing_consent": "1",
+		"old_customer_email":    defaultEmail,
+		"old_marketing_consent": "0",
+		"products":              "12345,678910",
+		"source":                "Checkout",
+		"updated_at":            defaultEventTime,
+		"user_id":               "1234",
+	},
+	subscription.CancelledAlertName: {
+		"alert_id":                    "1730031687",
+		"cancellation_effective_date": defaultDate,
+		"checkout_id":                 defaultCheckoutID,
+		"currency":                    "USD",
+		"custom_data":                 "custom data",
+		"email":                       defaultEmail,
+		"event_time":                  defaultEventTime,
+		"linked_subscriptions":        "",
+		"marketing_consent":           "1",
+		"passthrough":                 "Example String",
+		"quantity":                    "1",
+		"status":                      "deleted",
+		"subscription_id":             "4321",
+		"subscription_plan_id":        "5",
+		"unit_price":                  "49.99",
+		"user_id":                     "1234",
+	},
+	subscription.CreatedAlertName: {
+		"alert_id":             "1730031688",
+		"cancel_url":           "https://example.org/cancel",
+		"checkout_id":          defaultCheckoutID,
+		"currency":             "USD",
+		"custom_data":          "custom data",
+		"email":                defaultEmail,
+		"event_time":           defaultEventTime,
+		"linked_subscriptions": "",
+		"marketing_consent":    "1",
+		"next_bill_date":       defaultDate,
+		"passthrough":          "Example String",
+		"quantity":             "1",
+		"source":               "Checkout",
+		"status":               "active",
+		"subscription_id":      "4321",
+		"subscription_plan_id": "5",
+		"unit_price":           "49.99",
+		"update_url":           "https://example.org/update",
+		"user_id":              "1234",
+	},
+	subscription.PaymentFailedAlertName: {
+		"alert_id":                "1730031689",
+		"amount":                  "49.99",
+		"attempt_number":          "1",
+		"cancel_url":              "https://example.org/cancel",
+		"checkout_id":             defaultCheckoutID,
+		"currency":                "USD",
+		"custom_data":             "custom data",
+		"email":                   defaultEmail,
+		"event_time":              defaultEventTime,
+		"hard_failure":            "1",
+		"instalments":             "1",
+		"marketing_consent":       "1",
+		"next_retry_date":         defaultDate,
+		"order_id":                "123456",
+		"passthrough":             "Example String",
+		"quantity":                "1",
+		"status":                  "past_due",
+		"subscription_id":         "4321",
+		"subscription_payment_id": "777",
+		"subscription_plan_id":    "5",
+		"unit_price":              "49.99",
+		"update_url":              "https://example.org/update",
+		"user_id":                 "1234",
+	},
+	subscription.PaymentRefundedAlertName: {
+		"alert_id":                  "1730031690",
+		"amount":                    "49.99",
+		"balance_currency":          "USD",
+		"balance_earnings_decrease": "42.49",
+		"balance_fee_refund":        "2.50",
+		"balance_gross_refund":      "49.99",
+		"balance_tax_refund":        "5.00",
+		"checkout_id":               defaultCheckoutID,
+		"currency":                  "USD",
+		"earnings_decrease":         "42.49",
+		"email":                     defaultEmail,
+		"event_time":                defaultEventTime,
+		"fee_refund":                "2.50",
+		"gross_refund":              "49.99",
+		"initial_payment":           "0",
+		"instalments":               "1",
+		"marketing_consent":         "1",
+		"order_id":                  "123456",
+		"passthrough":               "Example String",
+		"quantity":                  "1",
+		"refund_reason":             "Customer request",
+		"refund_type":               "full",
+		"status":                    "active",
+		"subscription_id":           "4321",
+		"subscription_payment_id":   "777",
+		"subscription_plan_id":      "5",
+		"tax_refund":                "5.00",
+		"unit_price":                "49.99",
+		"user_id":                   "1234",
+	},
+	subscription.PaymentSucceededAlertName: {
+		"alert_id":                "1730031691",
+		"balance_currency":        "USD",
+		"balance_earnings":        "42.49",
+		"balance_fee":             "2.50",
+		"balance_gross":           "49.99",
+		"balance_tax":             "5.00",
+		"checkout_id":             defaultCheckoutID,
+		"country":                 "PL",
+		"coupon":                  "",
+		"currency":                "USD",
+		"custom_data":             "custom data",
+		"customer_name":           "Jan Kowalski",
+		"earnings":                "42.49",
+		"email":                   defaultEmail,
+		"event_time":              defaultEventTime,
+		"fee":                     "2.50",
+		"initial_payment":         "1",
+		"instalments":             "1",
+		"marketing_consent":       "1",
+		"next_bill_date":          defaultDate,
+		"next_payment_amount":     "49.99",
+		"order_id":                "123456",
+		"passthrough":             "Example String",
+		"payment_method":          "card",
+		"payment_tax":             "5.00",
+		"plan_name":               "Premium",
+		"quantity":                "1",
+		"receipt_url":             "https://example.org/receipt",
+		"sale_gross":              "49.99",
+		"status":                  "active",
+		"subscription_id":         "4321",
+		"subscription_payment_id": "777",
+		"subscription_plan_id":    "5",
+		"unit_price":              "49.99",
+		"user_id":                 "1234",
+	},
+	subscription.UpdatedAlertName: {
+		"alert_id":                 "1730031692",
+		"cancel_url":               "https://example.org/cancel",
+		"checkout_id":              defaultCheckoutID,
+		"currency":                 "USD",
+		"custom_data":              "custom data",
+		"email":                    defaultEmail,
+		"event_time":               defaultEventTime,
+		"linked_subscriptions":     "",
+		"marketing_consent":        "1",
+		"new_price":                "99.98",
+		"new_quantity":             "2",
+		"new_unit_price":           "49.99",
+		"next_bill_date":           defaultDate,
+		"old_next_bill_date":       defaultDate,
+		"old_price":                "49.99",
+		"old_quantity":             "1",
+		"old_status":               "active",
+		"old_subscription_plan_id": "5",
+		"old_unit_price":           "49.99",
+		"passthrough":              "Example String",
+		"status":                   "active",
+		"subscription_id":          "4321",
+		"subscription_plan_id":     "5",
+		"update_url":               "https://example.org/update",
+		"user_id":                  "1234",
+	},
+}
+
+// AlertNames returns sorted names of alerts Defaults knows.
+func AlertNames() []string {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Defaults returns new copy of fields of alert with realistic values.
+// It returns false if alertName is not one of supported alerts.
+func Defaults(alertName string) (events.Fields, bool) {
+	d, ok := defaults[alertName]
+	if !ok {
+		return nil, false
+	}
+	f := make(events.Fields, len(d)+1)
+	for k, v := range d {
+		f[k] = v
+	}
+	f["alert_name"] = alertName
+	return f, true
+}
@@ -0,0 +1,106 @@
+// Package sim generates correctly signed paddle classic alerts, so
+// webhook handlers can be exercised without paddle sandbox account.
+package sim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/mime"
+)
+
+const signatureField = "p_signature"
+
+// GenerateKey generates new key of the size paddle uses.
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 4096)
+}
+
+// ParsePrivateKeyPEM parses PKCS#1 or PKCS#8 PEM encoded RSA private key.
+func ParsePrivateKeyPEM(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return rsaKey, nil
+}
+
+// PublicKeyPEM encodes key the same way paddle shows public key in dashboard.
+func PublicKeyPEM(key *rsa.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Signer signs alerts the way paddle does.
+type Signer struct {
+	Key *rsa.PrivateKey
+}
+
+// Sign sets p_signature of f to signature of its php serialized fields.
+func (s Signer) Sign(f events.Fields) error {
+	data, err := f.Serialize()
+	if err != nil {
+		return err
+	}
+	hashed := sha1.Sum(data)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA1, hashed[:])
+	if err != nil {
+		return err
+	}
+	f[signatureField] = base64.StdEncoding.EncodeToString(sig)
+	return nil
+}
+
+// Encode encodes f as application/x-www-form-urlencoded body with keys sorted.
+func Encode(f events.Fields) string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(url.QueryEscape(k))
+		buf.WriteByte('=')
+		buf.WriteString(url.QueryEscape(f[k]))
+	}
+	return buf.String()
+}
+
+// NewRequest creates POST request to target with f as form encoded body.
+func NewRequest(target string, f events.Fields) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader([]byte(Encode(f))))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(mime.ContentTypeHeader, mime.ApplicationForm)
+	return req, nil
+}
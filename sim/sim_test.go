@@ -0,0 +1,58 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/dennor/go-paddle/events"
+	"github.com/dennor/go-paddle/events/test"
+	"github.com/dennor/go-paddle/middleware"
+	"github.com/dennor/go-paddle/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaults(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(events.DefaultRegistry.Names(), AlertNames())
+	f, ok := Defaults("transfer_paid")
+	assert.True(ok)
+	f["amount"] = "1"
+	f, _ = Defaults("transfer_paid")
+	assert.Equal("1234.56", f["amount"], "defaults must be copied")
+	_, ok = Defaults("unknown")
+	assert.False(ok)
+}
+
+func TestSign(t *testing.T) {
+	getEvent := (&middleware.Event{EventConfig: middleware.EventConfig{
+		Verifier: events.RSAVerifier(signature.RSA{
+			PublicKey: &test.Key.PublicKey,
+		}),
+		SkipContext: true,
+	}}).EventFromRequest()
+	for _, name := range AlertNames() {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			f, _ := Defaults(name)
+			require.NoError(Signer{Key: test.Key}.Sign(f))
+			req, err := NewRequest("http://localhost/", f)
+			require.NoError(err)
+			ev, err := getEvent(req)
+			require.NoError(err, "typed event must verify")
+			expected, _ := events.DefaultRegistry.New(name)
+			assert.IsType(expected, ev)
+			assert.Equal(name, ev.GetAlertName())
+		})
+	}
+}
+
+func TestPEM(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	b, err := PublicKeyPEM(&test.Key.PublicKey)
+	require.NoError(err)
+	assert.Contains(string(b), "-----BEGIN PUBLIC KEY-----")
+	_, err = ParsePrivateKeyPEM([]byte("not a key"))
+	assert.Error(err)
+}